@@ -0,0 +1,271 @@
+package iec61850
+
+// #include <iec61850_client.h>
+import "C"
+import (
+	"errors"
+	"fmt"
+)
+
+// IedError the error codes of libiec61850, see IedClientError
+type IedError int
+
+const (
+	IED_ERROR_OK                                    IedError = C.IED_ERROR_OK
+	IED_ERROR_NOT_CONNECTED                         IedError = C.IED_ERROR_NOT_CONNECTED
+	IED_ERROR_ALREADY_CONNECTED                     IedError = C.IED_ERROR_ALREADY_CONNECTED
+	IED_ERROR_CONNECTION_LOST                       IedError = C.IED_ERROR_CONNECTION_LOST
+	IED_ERROR_SERVICE_NOT_SUPPORTED                 IedError = C.IED_ERROR_SERVICE_NOT_SUPPORTED
+	IED_ERROR_CONNECTION_REJECTED                   IedError = C.IED_ERROR_CONNECTION_REJECTED
+	IED_ERROR_OUTSTANDING_CALL_LIMIT_REACHED        IedError = C.IED_ERROR_OUTSTANDING_CALL_LIMIT_REACHED
+	IED_ERROR_USER_PROVIDED_INVALID_ARGUMENT        IedError = C.IED_ERROR_USER_PROVIDED_INVALID_ARGUMENT
+	IED_ERROR_ENABLE_REPORT_FAILED_DATASET_MISMATCH IedError = C.IED_ERROR_ENABLE_REPORT_FAILED_DATASET_MISMATCH
+	IED_ERROR_OBJECT_REFERENCE_INVALID              IedError = C.IED_ERROR_OBJECT_REFERENCE_INVALID
+	IED_ERROR_UNEXPECTED_VALUE_RECEIVED             IedError = C.IED_ERROR_UNEXPECTED_VALUE_RECEIVED
+	IED_ERROR_TIMEOUT                               IedError = C.IED_ERROR_TIMEOUT
+	IED_ERROR_ACCESS_DENIED                         IedError = C.IED_ERROR_ACCESS_DENIED
+	IED_ERROR_OBJECT_DOES_NOT_EXIST                 IedError = C.IED_ERROR_OBJECT_DOES_NOT_EXIST
+	IED_ERROR_OBJECT_EXISTS                         IedError = C.IED_ERROR_OBJECT_EXISTS
+	IED_ERROR_OBJECT_ACCESS_UNSUPPORTED             IedError = C.IED_ERROR_OBJECT_ACCESS_UNSUPPORTED
+	IED_ERROR_TYPE_INCONSISTENT                     IedError = C.IED_ERROR_TYPE_INCONSISTENT
+	IED_ERROR_TEMPORARILY_UNAVAILABLE               IedError = C.IED_ERROR_TEMPORARILY_UNAVAILABLE
+	IED_ERROR_OBJECT_UNDEFINED                      IedError = C.IED_ERROR_OBJECT_UNDEFINED
+	IED_ERROR_INVALID_ADDRESS                       IedError = C.IED_ERROR_INVALID_ADDRESS
+	IED_ERROR_HARDWARE_FAULT                        IedError = C.IED_ERROR_HARDWARE_FAULT
+	IED_ERROR_TYPE_UNSUPPORTED                      IedError = C.IED_ERROR_TYPE_UNSUPPORTED
+	IED_ERROR_OBJECT_ATTRIBUTE_INCONSISTENT         IedError = C.IED_ERROR_OBJECT_ATTRIBUTE_INCONSISTENT
+	IED_ERROR_OBJECT_VALUE_INVALID                  IedError = C.IED_ERROR_OBJECT_VALUE_INVALID
+	IED_ERROR_OBJECT_INVALIDATED                    IedError = C.IED_ERROR_OBJECT_INVALIDATED
+	IED_ERROR_MALFORMED_MESSAGE                     IedError = C.IED_ERROR_MALFORMED_MESSAGE
+	IED_ERROR_SERVICE_NOT_IMPLEMENTED               IedError = C.IED_ERROR_SERVICE_NOT_IMPLEMENTED
+	IED_ERROR_UNKNOWN                               IedError = C.IED_ERROR_UNKNOWN
+)
+
+// The errors returned by the client wrap an IedError, test them with errors.Is, e.g. errors.Is(err, ErrTimeout)
+var (
+	ErrNotConnected                      error = IED_ERROR_NOT_CONNECTED
+	ErrAlreadyConnected                  error = IED_ERROR_ALREADY_CONNECTED
+	ErrConnectionLost                    error = IED_ERROR_CONNECTION_LOST
+	ErrServiceNotSupported               error = IED_ERROR_SERVICE_NOT_SUPPORTED
+	ErrConnectionRejected                error = IED_ERROR_CONNECTION_REJECTED
+	ErrOutstandingCallLimitReached       error = IED_ERROR_OUTSTANDING_CALL_LIMIT_REACHED
+	ErrUserProvidedInvalidArgument       error = IED_ERROR_USER_PROVIDED_INVALID_ARGUMENT
+	ErrEnableReportFailedDatasetMismatch error = IED_ERROR_ENABLE_REPORT_FAILED_DATASET_MISMATCH
+	ErrObjectReferenceInvalid            error = IED_ERROR_OBJECT_REFERENCE_INVALID
+	ErrUnexpectedValueReceived           error = IED_ERROR_UNEXPECTED_VALUE_RECEIVED
+	ErrTimeout                           error = IED_ERROR_TIMEOUT
+	ErrAccessDenied                      error = IED_ERROR_ACCESS_DENIED
+	ErrObjectDoesNotExist                error = IED_ERROR_OBJECT_DOES_NOT_EXIST
+	ErrObjectExists                      error = IED_ERROR_OBJECT_EXISTS
+	ErrObjectAccessUnsupported           error = IED_ERROR_OBJECT_ACCESS_UNSUPPORTED
+	ErrTypeInconsistent                  error = IED_ERROR_TYPE_INCONSISTENT
+	ErrTemporarilyUnavailable            error = IED_ERROR_TEMPORARILY_UNAVAILABLE
+	ErrObjectUndefined                   error = IED_ERROR_OBJECT_UNDEFINED
+	ErrInvalidAddress                    error = IED_ERROR_INVALID_ADDRESS
+	ErrHardwareFault                     error = IED_ERROR_HARDWARE_FAULT
+	ErrTypeUnsupported                   error = IED_ERROR_TYPE_UNSUPPORTED
+	ErrObjectAttributeInconsistent       error = IED_ERROR_OBJECT_ATTRIBUTE_INCONSISTENT
+	ErrObjectValueInvalid                error = IED_ERROR_OBJECT_VALUE_INVALID
+	ErrObjectInvalidated                 error = IED_ERROR_OBJECT_INVALIDATED
+	ErrMalformedMessage                  error = IED_ERROR_MALFORMED_MESSAGE
+	ErrServiceNotImplemented             error = IED_ERROR_SERVICE_NOT_IMPLEMENTED
+	ErrUnknown                           error = IED_ERROR_UNKNOWN
+)
+
+// ErrClientClosed returned by every call on a client after Close
+var ErrClientClosed = errors.New("client is closed")
+
+// ErrReaderClosed returned by Read of a DataSetReader after Close
+var ErrReaderClosed = errors.New("dataset reader is closed")
+
+func (e IedError) String() string {
+	switch e {
+	case IED_ERROR_OK:
+		return "IED_ERROR_OK"
+	case IED_ERROR_NOT_CONNECTED:
+		return "IED_ERROR_NOT_CONNECTED"
+	case IED_ERROR_ALREADY_CONNECTED:
+		return "IED_ERROR_ALREADY_CONNECTED"
+	case IED_ERROR_CONNECTION_LOST:
+		return "IED_ERROR_CONNECTION_LOST"
+	case IED_ERROR_SERVICE_NOT_SUPPORTED:
+		return "IED_ERROR_SERVICE_NOT_SUPPORTED"
+	case IED_ERROR_CONNECTION_REJECTED:
+		return "IED_ERROR_CONNECTION_REJECTED"
+	case IED_ERROR_OUTSTANDING_CALL_LIMIT_REACHED:
+		return "IED_ERROR_OUTSTANDING_CALL_LIMIT_REACHED"
+	case IED_ERROR_USER_PROVIDED_INVALID_ARGUMENT:
+		return "IED_ERROR_USER_PROVIDED_INVALID_ARGUMENT"
+	case IED_ERROR_ENABLE_REPORT_FAILED_DATASET_MISMATCH:
+		return "IED_ERROR_ENABLE_REPORT_FAILED_DATASET_MISMATCH"
+	case IED_ERROR_OBJECT_REFERENCE_INVALID:
+		return "IED_ERROR_OBJECT_REFERENCE_INVALID"
+	case IED_ERROR_UNEXPECTED_VALUE_RECEIVED:
+		return "IED_ERROR_UNEXPECTED_VALUE_RECEIVED"
+	case IED_ERROR_TIMEOUT:
+		return "IED_ERROR_TIMEOUT"
+	case IED_ERROR_ACCESS_DENIED:
+		return "IED_ERROR_ACCESS_DENIED"
+	case IED_ERROR_OBJECT_DOES_NOT_EXIST:
+		return "IED_ERROR_OBJECT_DOES_NOT_EXIST"
+	case IED_ERROR_OBJECT_EXISTS:
+		return "IED_ERROR_OBJECT_EXISTS"
+	case IED_ERROR_OBJECT_ACCESS_UNSUPPORTED:
+		return "IED_ERROR_OBJECT_ACCESS_UNSUPPORTED"
+	case IED_ERROR_TYPE_INCONSISTENT:
+		return "IED_ERROR_TYPE_INCONSISTENT"
+	case IED_ERROR_TEMPORARILY_UNAVAILABLE:
+		return "IED_ERROR_TEMPORARILY_UNAVAILABLE"
+	case IED_ERROR_OBJECT_UNDEFINED:
+		return "IED_ERROR_OBJECT_UNDEFINED"
+	case IED_ERROR_INVALID_ADDRESS:
+		return "IED_ERROR_INVALID_ADDRESS"
+	case IED_ERROR_HARDWARE_FAULT:
+		return "IED_ERROR_HARDWARE_FAULT"
+	case IED_ERROR_TYPE_UNSUPPORTED:
+		return "IED_ERROR_TYPE_UNSUPPORTED"
+	case IED_ERROR_OBJECT_ATTRIBUTE_INCONSISTENT:
+		return "IED_ERROR_OBJECT_ATTRIBUTE_INCONSISTENT"
+	case IED_ERROR_OBJECT_VALUE_INVALID:
+		return "IED_ERROR_OBJECT_VALUE_INVALID"
+	case IED_ERROR_OBJECT_INVALIDATED:
+		return "IED_ERROR_OBJECT_INVALIDATED"
+	case IED_ERROR_MALFORMED_MESSAGE:
+		return "IED_ERROR_MALFORMED_MESSAGE"
+	case IED_ERROR_SERVICE_NOT_IMPLEMENTED:
+		return "IED_ERROR_SERVICE_NOT_IMPLEMENTED"
+	case IED_ERROR_UNKNOWN:
+		return "IED_ERROR_UNKNOWN"
+	}
+
+	return "IED_ERROR_UNDEFINED"
+}
+
+func (e IedError) Error() string {
+	return e.String()
+}
+
+// ClientError the failed operation, the object it was applied to and the IedError reported by libiec61850
+type ClientError struct {
+	Op   string
+	Ref  string
+	Code IedError
+}
+
+func (e *ClientError) Error() string {
+	if e.Ref == "" {
+		return fmt.Sprintf("failed to %s, clientError: %v", e.Op, e.Code)
+	}
+	return fmt.Sprintf("failed to %s %s, clientError: %v", e.Op, e.Ref, e.Code)
+}
+
+func (e *ClientError) Unwrap() error {
+	return e.Code
+}
+
+func newClientError(operation string, objectRef string, clientError C.IedClientError) error {
+	return &ClientError{Op: operation, Ref: objectRef, Code: IedError(clientError)}
+}
+
+// mmsErrorToIedError map the error of an MMS service like libiec61850 does for the IedConnection services
+func mmsErrorToIedError(mmsError C.MmsError) IedError {
+	switch mmsError {
+	case C.MMS_ERROR_NONE:
+		return IED_ERROR_OK
+	case C.MMS_ERROR_CONNECTION_LOST:
+		return IED_ERROR_CONNECTION_LOST
+	case C.MMS_ERROR_CONNECTION_REJECTED:
+		return IED_ERROR_CONNECTION_REJECTED
+	case C.MMS_ERROR_SERVICE_TIMEOUT:
+		return IED_ERROR_TIMEOUT
+	case C.MMS_ERROR_PARSING_RESPONSE:
+		return IED_ERROR_MALFORMED_MESSAGE
+	case C.MMS_ERROR_HARDWARE_FAULT:
+		return IED_ERROR_HARDWARE_FAULT
+	case C.MMS_ERROR_INVALID_ARGUMENTS:
+		return IED_ERROR_USER_PROVIDED_INVALID_ARGUMENT
+	case C.MMS_ERROR_OUTSTANDING_CALL_LIMIT:
+		return IED_ERROR_OUTSTANDING_CALL_LIMIT_REACHED
+	case C.MMS_ERROR_DEFINITION_INVALID_ADDRESS:
+		return IED_ERROR_INVALID_ADDRESS
+	case C.MMS_ERROR_DEFINITION_TYPE_UNSUPPORTED:
+		return IED_ERROR_TYPE_UNSUPPORTED
+	case C.MMS_ERROR_DEFINITION_TYPE_INCONSISTENT:
+		return IED_ERROR_TYPE_INCONSISTENT
+	case C.MMS_ERROR_DEFINITION_OBJECT_UNDEFINED:
+		return IED_ERROR_OBJECT_UNDEFINED
+	case C.MMS_ERROR_DEFINITION_OBJECT_EXISTS:
+		return IED_ERROR_OBJECT_EXISTS
+	case C.MMS_ERROR_DEFINITION_OBJECT_ATTRIBUTE_INCONSISTENT:
+		return IED_ERROR_OBJECT_ATTRIBUTE_INCONSISTENT
+	case C.MMS_ERROR_ACCESS_OBJECT_NON_EXISTENT:
+		return IED_ERROR_OBJECT_DOES_NOT_EXIST
+	case C.MMS_ERROR_ACCESS_OBJECT_ACCESS_UNSUPPORTED:
+		return IED_ERROR_OBJECT_ACCESS_UNSUPPORTED
+	case C.MMS_ERROR_ACCESS_OBJECT_ACCESS_DENIED:
+		return IED_ERROR_ACCESS_DENIED
+	case C.MMS_ERROR_ACCESS_OBJECT_INVALIDATED:
+		return IED_ERROR_OBJECT_INVALIDATED
+	case C.MMS_ERROR_ACCESS_OBJECT_VALUE_INVALID:
+		return IED_ERROR_OBJECT_VALUE_INVALID
+	case C.MMS_ERROR_ACCESS_TEMPORARILY_UNAVAILABLE:
+		return IED_ERROR_TEMPORARILY_UNAVAILABLE
+	case C.MMS_ERROR_REJECT_UNRECOGNIZED_SERVICE:
+		return IED_ERROR_SERVICE_NOT_SUPPORTED
+	}
+
+	return IED_ERROR_UNKNOWN
+}
+
+// dataAccessErrorToIedError map the data access error of a single variable
+func dataAccessErrorToIedError(accessError DataAccessError) IedError {
+	switch accessError {
+	case C.DATA_ACCESS_ERROR_SUCCESS:
+		return IED_ERROR_OK
+	case C.DATA_ACCESS_ERROR_OBJECT_INVALIDATED:
+		return IED_ERROR_OBJECT_INVALIDATED
+	case C.DATA_ACCESS_ERROR_HARDWARE_FAULT:
+		return IED_ERROR_HARDWARE_FAULT
+	case C.DATA_ACCESS_ERROR_TEMPORARILY_UNAVAILABLE:
+		return IED_ERROR_TEMPORARILY_UNAVAILABLE
+	case C.DATA_ACCESS_ERROR_OBJECT_ACCESS_DENIED:
+		return IED_ERROR_ACCESS_DENIED
+	case C.DATA_ACCESS_ERROR_OBJECT_UNDEFINED:
+		return IED_ERROR_OBJECT_UNDEFINED
+	case C.DATA_ACCESS_ERROR_INVALID_ADDRESS:
+		return IED_ERROR_INVALID_ADDRESS
+	case C.DATA_ACCESS_ERROR_TYPE_UNSUPPORTED:
+		return IED_ERROR_TYPE_UNSUPPORTED
+	case C.DATA_ACCESS_ERROR_TYPE_INCONSISTENT:
+		return IED_ERROR_TYPE_INCONSISTENT
+	case C.DATA_ACCESS_ERROR_OBJECT_ATTRIBUTE_INCONSISTENT:
+		return IED_ERROR_OBJECT_ATTRIBUTE_INCONSISTENT
+	case C.DATA_ACCESS_ERROR_OBJECT_ACCESS_UNSUPPORTED:
+		return IED_ERROR_OBJECT_ACCESS_UNSUPPORTED
+	case C.DATA_ACCESS_ERROR_OBJECT_NONE_EXISTENT:
+		return IED_ERROR_OBJECT_DOES_NOT_EXIST
+	case C.DATA_ACCESS_ERROR_OBJECT_VALUE_INVALID:
+		return IED_ERROR_OBJECT_VALUE_INVALID
+	}
+
+	return IED_ERROR_UNKNOWN
+}
+
+// MmsTypeError a Go value of an MMS type libiec61850 can not create, e.g. GeneralizedTime, BCD or ObjectID. It
+// wraps ErrTypeUnsupported.
+type MmsTypeError struct {
+	Type MMSType
+}
+
+func (e *MmsTypeError) Error() string {
+	return fmt.Sprintf("unsupported mms type: %d", e.Type)
+}
+
+func (e *MmsTypeError) Unwrap() error {
+	return ErrTypeUnsupported
+}
+
+// Err get real ied error type
+func Err(e C.IedClientError) string {
+	return IedError(e).String()
+}
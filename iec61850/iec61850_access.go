@@ -0,0 +1,122 @@
+package iec61850
+
+/*
+#include <stdint.h>
+#include <stdlib.h>
+#include <iec61850_server.h>
+
+extern MmsDataAccessError goReadAccessHandler(LogicalDevice* ld, LogicalNode* ln, DataObject* dataObject, FunctionalConstraint fc, ClientConnection connection, void* parameter);
+extern MmsDataAccessError goWriteAccessHandler(DataAttribute* dataAttribute, MmsValue* value, ClientConnection connection, void* parameter);
+extern CheckHandlerResult goControlCheckHandler(ControlAction action, void* parameter, MmsValue* ctlVal, bool test, bool interlockCheck);
+
+static void setReadAccessHandler(IedServer self, uintptr_t id)
+{
+	IedServer_setReadAccessHandler(self, goReadAccessHandler, (void*) id);
+}
+
+static void handleWriteAccess(IedServer self, DataAttribute* dataAttribute, uintptr_t id)
+{
+	IedServer_handleWriteAccess(self, dataAttribute, goWriteAccessHandler, (void*) id);
+}
+
+static void setPerformCheckHandler(IedServer self, DataObject* node, uintptr_t id)
+{
+	IedServer_setPerformCheckHandler(self, node, goControlCheckHandler, (void*) id);
+}
+*/
+import "C"
+import "unsafe"
+
+// ControlCheck a select or operate of a control object, checked by the handler given to OnControlCheck
+type ControlCheck struct {
+	Select         bool
+	CtlNum         int
+	CtlVal         MmsValue
+	Test           bool
+	InterlockCheck bool
+}
+
+// serverValues convert the values written by clients, a server has no client options
+var serverValues = &IedClient{}
+
+// OnReadAccess call handler before a client reads a data object, the read is denied when it returns false.
+// objectRef is the reference of the data object, or of the logical node or device if the whole of it is read.
+func (is *IedServer) OnReadAccess(handler func(connection ClientConnection, objectRef string, constraint FunctionalConstraint) bool) {
+	callbacks := is.getCallbacks()
+
+	callbacks.mutex.Lock()
+	callbacks.readHandler = handler
+	callbacks.mutex.Unlock()
+
+	C.setReadAccessHandler(is.server, C.uintptr_t(callbacks.id))
+}
+
+// OnWriteAccess call handler before a client writes the attribute, the write is denied when it returns false
+func (is *IedServer) OnWriteAccess(attribute *DataAttribute, handler func(connection ClientConnection, value MmsValue) bool) {
+	callbacks := is.getCallbacks()
+
+	callbacks.mutex.Lock()
+	callbacks.writeHandlers[attribute.attribute] = handler
+	callbacks.mutex.Unlock()
+
+	C.handleWriteAccess(is.server, attribute.attribute, C.uintptr_t(callbacks.id))
+}
+
+// OnControlCheck call handler before a client selects or operates the control object, the control is rejected with
+// access denied when it returns false
+func (is *IedServer) OnControlCheck(object *DataObject, handler func(connection ClientConnection, check ControlCheck) bool) {
+	callbacks := is.getCallbacks()
+
+	callbacks.mutex.Lock()
+	callbacks.controlHandlers[object.object] = handler
+	callbacks.mutex.Unlock()
+
+	C.setPerformCheckHandler(is.server, object.object, C.uintptr_t(callbacks.id))
+}
+
+func (callbacks *serverCallbacks) checkRead(connection C.ClientConnection, node *C.ModelNode, constraint FunctionalConstraint) bool {
+	callbacks.mutex.Lock()
+	handler := callbacks.readHandler
+	callbacks.mutex.Unlock()
+
+	if handler == nil {
+		return true
+	}
+
+	cObjectRef := C.ModelNode_getObjectReference(node, nil)
+	defer C.free(unsafe.Pointer(cObjectRef))
+
+	return handler(callbacks.lookupConnection(connection), C.GoString(cObjectRef), constraint)
+}
+
+func (callbacks *serverCallbacks) checkWrite(connection C.ClientConnection, attribute *C.DataAttribute, value *C.MmsValue) bool {
+	callbacks.mutex.Lock()
+	handler := callbacks.writeHandlers[attribute]
+	callbacks.mutex.Unlock()
+
+	if handler == nil {
+		return true
+	}
+
+	return handler(callbacks.lookupConnection(connection), serverValues.toMmsValue(value))
+}
+
+func (callbacks *serverCallbacks) checkControl(action C.ControlAction, ctlVal *C.MmsValue, test bool, interlockCheck bool) bool {
+	callbacks.mutex.Lock()
+	handler := callbacks.controlHandlers[C.ControlAction_getControlObject(action)]
+	callbacks.mutex.Unlock()
+
+	if handler == nil {
+		return true
+	}
+
+	check := ControlCheck{
+		Select:         bool(C.ControlAction_isSelect(action)),
+		CtlNum:         int(C.ControlAction_getCtlNum(action)),
+		CtlVal:         serverValues.toMmsValue(ctlVal),
+		Test:           test,
+		InterlockCheck: interlockCheck,
+	}
+
+	return handler(callbacks.lookupConnection(C.ControlAction_getClientConnection(action)), check)
+}
@@ -0,0 +1,161 @@
+package iec61850
+
+/*
+#include <stdlib.h>
+#include <iec61850_client.h>
+*/
+import "C"
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"unsafe"
+
+	"github.com/weiheng-tech/go-libiec61850/iec61850/scl_xml"
+)
+
+const (
+	maxPSelectorSize = 16
+	maxSSelectorSize = 16
+	maxTSelectorSize = 4
+)
+
+// IsoAddress the ISO/ACSE addressing of one side of an association. An empty ApTitle or a nil selector keeps the
+// default of libiec61850, an empty but not nil selector is not sent.
+type IsoAddress struct {
+	// ApTitle the AP-Title as object identifier, e.g. "1.1.1.999"
+	ApTitle     string
+	AeQualifier int
+	PSelector   []byte
+	SSelector   []byte
+	TSelector   []byte
+}
+
+// LocalAddress the calling AP-Title, AE-Qualifier and selectors
+func LocalAddress(address IsoAddress) func(*IedClient) {
+	return func(c *IedClient) {
+		c.setIsoAddress(address, false)
+	}
+}
+
+// RemoteAddress the called AP-Title, AE-Qualifier and selectors, they have to match the configuration of the IED
+func RemoteAddress(address IsoAddress) func(*IedClient) {
+	return func(c *IedClient) {
+		c.setIsoAddress(address, true)
+	}
+}
+
+// IsoAddressFromSCL the addressing of an access point from the P elements of Communication/ConnectedAP/Address
+func IsoAddressFromSCL(connectedAP *scl_xml.ConnectedAP) (IsoAddress, error) {
+	var address IsoAddress
+
+	if apTitle, ok := connectedAP.GetAddress("OSI-AP-Title"); ok {
+		// SCL separates the arcs by commas
+		address.ApTitle = strings.ReplaceAll(strings.ReplaceAll(apTitle, ",", "."), " ", "")
+	}
+
+	if aeQualifier, ok := connectedAP.GetAddress("OSI-AE-Qualifier"); ok {
+		value, err := strconv.Atoi(aeQualifier)
+		if err != nil {
+			return address, fmt.Errorf("invalid OSI-AE-Qualifier %s: %v", aeQualifier, err)
+		}
+		address.AeQualifier = value
+	}
+
+	selectors := []struct {
+		typ      string
+		selector *[]byte
+	}{
+		{"OSI-PSEL", &address.PSelector},
+		{"OSI-SSEL", &address.SSelector},
+		{"OSI-TSEL", &address.TSelector},
+	}
+
+	for _, s := range selectors {
+		value, ok := connectedAP.GetAddress(s.typ)
+		if !ok {
+			continue
+		}
+
+		selector, err := hex.DecodeString(value)
+		if err != nil {
+			return address, fmt.Errorf("invalid %s %s: %v", s.typ, value, err)
+		}
+		*s.selector = selector
+	}
+
+	return address, address.validate()
+}
+
+func (address IsoAddress) validate() error {
+	if len(address.PSelector) > maxPSelectorSize {
+		return fmt.Errorf("invalid p-selector size %d, maximum is %d", len(address.PSelector), maxPSelectorSize)
+	}
+	if len(address.SSelector) > maxSSelectorSize {
+		return fmt.Errorf("invalid s-selector size %d, maximum is %d", len(address.SSelector), maxSSelectorSize)
+	}
+	if len(address.TSelector) > maxTSelectorSize {
+		return fmt.Errorf("invalid t-selector size %d, maximum is %d", len(address.TSelector), maxTSelectorSize)
+	}
+	if address.ApTitle == "" {
+		// the ae-qualifier is only set together with the ap-title
+		if address.AeQualifier != 0 {
+			return fmt.Errorf("invalid ae-qualifier %d without ap-title", address.AeQualifier)
+		}
+		return nil
+	}
+	for _, arc := range strings.Split(address.ApTitle, ".") {
+		if _, err := strconv.ParseUint(arc, 10, 16); err != nil {
+			return fmt.Errorf("invalid ap-title %s", address.ApTitle)
+		}
+	}
+	return nil
+}
+
+// setIsoAddress an invalid address is reported by Connect, since options can not return an error
+func (client *IedClient) setIsoAddress(address IsoAddress, remote bool) {
+	if err := address.validate(); err != nil {
+		client.optionErr = err
+		return
+	}
+
+	parameters := C.MmsConnection_getIsoConnectionParameters(C.IedConnection_getMmsConnection(client.connection))
+
+	if address.ApTitle != "" {
+		cApTitle := C.CString(address.ApTitle)
+		defer C.free(unsafe.Pointer(cApTitle))
+
+		if remote {
+			C.IsoConnectionParameters_setRemoteApTitle(parameters, cApTitle, C.int(address.AeQualifier))
+		} else {
+			C.IsoConnectionParameters_setLocalApTitle(parameters, cApTitle, C.int(address.AeQualifier))
+		}
+	}
+
+	if remote {
+		pSelector, sSelector, tSelector := parameters.remotePSelector, parameters.remoteSSelector, parameters.remoteTSelector
+		setSelector(address.PSelector, &pSelector.size, pSelector.value[:])
+		setSelector(address.SSelector, &sSelector.size, sSelector.value[:])
+		setSelector(address.TSelector, &tSelector.size, tSelector.value[:])
+		C.IsoConnectionParameters_setRemoteAddresses(parameters, pSelector, sSelector, tSelector)
+	} else {
+		pSelector, sSelector, tSelector := parameters.localPSelector, parameters.localSSelector, parameters.localTSelector
+		setSelector(address.PSelector, &pSelector.size, pSelector.value[:])
+		setSelector(address.SSelector, &sSelector.size, sSelector.value[:])
+		setSelector(address.TSelector, &tSelector.size, tSelector.value[:])
+		C.IsoConnectionParameters_setLocalAddresses(parameters, pSelector, sSelector, tSelector)
+	}
+}
+
+// setSelector a nil selector keeps the current value
+func setSelector(selector []byte, size *C.uint8_t, value []C.uint8_t) {
+	if selector == nil {
+		return
+	}
+
+	*size = C.uint8_t(len(selector))
+	for i, b := range selector {
+		value[i] = C.uint8_t(b)
+	}
+}
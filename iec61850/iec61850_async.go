@@ -0,0 +1,365 @@
+package iec61850
+
+/*
+#include <stdint.h>
+#include <iec61850_client.h>
+
+extern void goReadObjectHandler(uint32_t invokeId, void* parameter, IedClientError err, MmsValue* value);
+extern void goGenericServiceHandler(uint32_t invokeId, void* parameter, IedClientError err);
+extern void goReadDataSetHandler(uint32_t invokeId, void* parameter, IedClientError err, ClientDataSet dataSet);
+extern void goVariableSpecHandler(uint32_t invokeId, void* parameter, IedClientError err, MmsVariableSpecification* spec);
+extern void goDataSetDirectoryHandler(uint32_t invokeId, void* parameter, IedClientError err, LinkedList dataSetDirectory, bool isDeletable);
+
+static uint32_t readObjectAsync(IedConnection self, IedClientError* error, const char* objRef, FunctionalConstraint fc, uintptr_t id)
+{
+	return IedConnection_readObjectAsync(self, error, objRef, fc, goReadObjectHandler, (void*) id);
+}
+
+static uint32_t writeObjectAsync(IedConnection self, IedClientError* error, const char* objRef, FunctionalConstraint fc, MmsValue* value, uintptr_t id)
+{
+	return IedConnection_writeObjectAsync(self, error, objRef, fc, value, goGenericServiceHandler, (void*) id);
+}
+
+static uint32_t readDataSetValuesAsync(IedConnection self, IedClientError* error, const char* dataSetReference, uintptr_t id)
+{
+	return IedConnection_readDataSetValuesAsync(self, error, dataSetReference, NULL, goReadDataSetHandler, (void*) id);
+}
+
+static uint32_t getVariableSpecificationAsync(IedConnection self, IedClientError* error, const char* objRef, FunctionalConstraint fc, uintptr_t id)
+{
+	return IedConnection_getVariableSpecificationAsync(self, error, objRef, fc, goVariableSpecHandler, (void*) id);
+}
+
+static uint32_t getDataSetDirectoryAsync(IedConnection self, IedClientError* error, const char* dataSetReference, uintptr_t id)
+{
+	return IedConnection_getDataSetDirectoryAsync(self, error, dataSetReference, goDataSetDirectoryHandler, (void*) id);
+}
+*/
+import "C"
+import (
+	"fmt"
+	"sync"
+	"time"
+	"unsafe"
+)
+
+// AsyncResult the result of an asynchronous request. Value is set for object reads, Values for data set reads, Spec for
+// type specifications and Directory for data set directories.
+type AsyncResult struct {
+	InvokeId  uint32
+	Value     MmsValue
+	Values    []MmsValue
+	Spec      *MmsVariableSpecification
+	Directory *DataSetDirectory
+	Err       error
+}
+
+// AsyncCall an outstanding asynchronous request. The result is delivered once, either to the handler given when the
+// request was sent or, if there is no handler, to the Done channel.
+type AsyncCall struct {
+	InvokeId uint32
+
+	client    *IedClient
+	operation string
+	objectRef string
+
+	id      uintptr
+	done    chan AsyncResult
+	handler func(AsyncResult)
+	timer   *time.Timer
+	once    sync.Once
+}
+
+var (
+	asyncCallsMutex sync.Mutex
+	asyncCalls      = make(map[uintptr]*AsyncCall)
+	asyncCallId     uintptr
+)
+
+func (call *AsyncCall) Done() <-chan AsyncResult {
+	return call.done
+}
+
+// newAsyncCall register a call, the id is passed to the C callback to find the call again
+func (client *IedClient) newAsyncCall(operation string, objectRef string, handler func(AsyncResult)) *AsyncCall {
+	call := &AsyncCall{
+		client:    client,
+		operation: operation,
+		objectRef: objectRef,
+		done:      make(chan AsyncResult, 1),
+		handler:   handler,
+	}
+
+	asyncCallsMutex.Lock()
+	asyncCallId++
+	call.id = asyncCallId
+	asyncCalls[call.id] = call
+	asyncCallsMutex.Unlock()
+
+	return call
+}
+
+// takeAsyncCall remove the call from the registry, nil if it was already completed (e.g. by its timeout)
+func takeAsyncCall(id uintptr) *AsyncCall {
+	asyncCallsMutex.Lock()
+	defer asyncCallsMutex.Unlock()
+
+	call, ok := asyncCalls[id]
+	if !ok {
+		return nil
+	}
+	delete(asyncCalls, id)
+	return call
+}
+
+// failAsyncCalls complete the outstanding calls of a closing client, late responses are dropped by the callbacks
+func failAsyncCalls(client *IedClient) {
+	asyncCallsMutex.Lock()
+	var calls []*AsyncCall
+	for id, call := range asyncCalls {
+		if call.client == client {
+			calls = append(calls, call)
+			delete(asyncCalls, id)
+		}
+	}
+	asyncCallsMutex.Unlock()
+
+	for _, call := range calls {
+		call.complete(AsyncResult{
+			InvokeId: call.InvokeId,
+			Err:      fmt.Errorf("failed to %s %s, %w", call.operation, call.objectRef, ErrClientClosed),
+		})
+	}
+}
+
+// start arm the per request timeout after the request was sent, timeout <= 0 relies on the RequestTimeout of the client.
+// The requests sent with sendWithTimeout expire in libiec61850 after the same timeout, the asynchronous control
+// requests keep their outstanding call until the RequestTimeout of the client.
+func (call *AsyncCall) start(invokeId uint32, timeout time.Duration) {
+	asyncCallsMutex.Lock()
+	defer asyncCallsMutex.Unlock()
+
+	call.InvokeId = invokeId
+
+	// the response may already have been received
+	if _, ok := asyncCalls[call.id]; !ok || timeout <= 0 {
+		return
+	}
+
+	call.timer = time.AfterFunc(timeout, func() {
+		if takeAsyncCall(call.id) == nil {
+			return
+		}
+		call.complete(AsyncResult{
+			InvokeId: invokeId,
+			Err:      call.error(C.IED_ERROR_TIMEOUT),
+		})
+	})
+}
+
+// sendWithTimeout send a request whose outstanding call in libiec61850 expires after timeout, so the request does not
+// keep its outstanding call when the Go side gave up. timeout <= 0 or longer than the RequestTimeout of the client
+// keeps the RequestTimeout. The RequestTimeout is a setting of the connection, a blocking request started while the
+// asynchronous one is sent expires after the shorter timeout as well.
+func (client *IedClient) sendWithTimeout(timeout time.Duration, send func()) {
+	if timeout <= 0 {
+		send()
+		return
+	}
+
+	client.requestTimeoutMutex.Lock()
+	defer client.requestTimeoutMutex.Unlock()
+
+	requestTimeout := C.IedConnection_getRequestTimeout(client.connection)
+	timeoutInMs := C.uint32_t((timeout + time.Millisecond - 1) / time.Millisecond)
+	if timeoutInMs >= requestTimeout {
+		send()
+		return
+	}
+
+	C.IedConnection_setRequestTimeout(client.connection, timeoutInMs)
+	defer C.IedConnection_setRequestTimeout(client.connection, requestTimeout)
+
+	send()
+}
+
+// abort the request could not be sent, the callback will never be invoked
+func (call *AsyncCall) abort(clientError C.IedClientError) error {
+	takeAsyncCall(call.id)
+	return call.error(clientError)
+}
+
+func (call *AsyncCall) error(clientError C.IedClientError) error {
+	return newClientError(call.operation, call.objectRef, clientError)
+}
+
+// cancel stop waiting for the response, a late response is dropped by the callback. The outstanding call of
+// libiec61850 expires with the timeout the request was sent with.
+func (call *AsyncCall) cancel() {
+	if takeAsyncCall(call.id) == nil {
+		return
+	}
+
+	call.once.Do(func() {
+		if call.timer != nil {
+			call.timer.Stop()
+		}
+	})
+}
+
+// complete deliver the result, the handler runs in its own goroutine so it may use the client again
+func (call *AsyncCall) complete(result AsyncResult) {
+	call.once.Do(func() {
+		if call.timer != nil {
+			call.timer.Stop()
+		}
+
+		if call.handler != nil {
+			go call.handler(result)
+			return
+		}
+		call.done <- result
+	})
+}
+
+// ReadObjectAsync send a read request without waiting for the response, see ReadObject
+func (client *IedClient) ReadObjectAsync(objectRef string, constraint FunctionalConstraint, timeout time.Duration, handler func(AsyncResult)) (*AsyncCall, error) {
+	if err := client.acquire(); err != nil {
+		return nil, err
+	}
+	defer client.release()
+
+	cObjectRef := C.CString(objectRef)
+	defer C.free(unsafe.Pointer(cObjectRef))
+
+	call := client.newAsyncCall("read object", objectRef, handler)
+
+	var clientError C.IedClientError
+	var invokeId C.uint32_t
+	client.sendWithTimeout(timeout, func() {
+		invokeId = C.readObjectAsync(client.connection, &clientError, cObjectRef, C.FunctionalConstraint(constraint), C.uintptr_t(call.id))
+	})
+
+	if clientError != C.IED_ERROR_OK {
+		return nil, call.abort(clientError)
+	}
+
+	call.start(uint32(invokeId), timeout)
+
+	return call, nil
+}
+
+// WriteObjectAsync send a write request without waiting for the response, see WriteObject
+func (client *IedClient) WriteObjectAsync(objectRef string, constraint FunctionalConstraint, value MmsValue, timeout time.Duration, handler func(AsyncResult)) (*AsyncCall, error) {
+	if err := client.acquire(); err != nil {
+		return nil, err
+	}
+	defer client.release()
+
+	mmsValue, err := newMmsValue(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write object %s, %w", objectRef, err)
+	}
+	defer C.MmsValue_delete(mmsValue)
+
+	cObjectRef := C.CString(objectRef)
+	defer C.free(unsafe.Pointer(cObjectRef))
+
+	call := client.newAsyncCall("write object", objectRef, handler)
+
+	var clientError C.IedClientError
+	var invokeId C.uint32_t
+	client.sendWithTimeout(timeout, func() {
+		invokeId = C.writeObjectAsync(client.connection, &clientError, cObjectRef, C.FunctionalConstraint(constraint), mmsValue, C.uintptr_t(call.id))
+	})
+
+	if clientError != C.IED_ERROR_OK {
+		return nil, call.abort(clientError)
+	}
+
+	call.start(uint32(invokeId), timeout)
+
+	return call, nil
+}
+
+// ReadDataSetValuesAsync send a data set read request without waiting for the response, see ReadDataSetValues
+func (client *IedClient) ReadDataSetValuesAsync(dataSetReference string, timeout time.Duration, handler func(AsyncResult)) (*AsyncCall, error) {
+	if err := client.acquire(); err != nil {
+		return nil, err
+	}
+	defer client.release()
+
+	cDataSetReference := C.CString(dataSetReference)
+	defer C.free(unsafe.Pointer(cDataSetReference))
+
+	call := client.newAsyncCall("read dataset values", dataSetReference, handler)
+
+	var clientError C.IedClientError
+	var invokeId C.uint32_t
+	client.sendWithTimeout(timeout, func() {
+		invokeId = C.readDataSetValuesAsync(client.connection, &clientError, cDataSetReference, C.uintptr_t(call.id))
+	})
+
+	if clientError != C.IED_ERROR_OK {
+		return nil, call.abort(clientError)
+	}
+
+	call.start(uint32(invokeId), timeout)
+
+	return call, nil
+}
+
+// GetTypeSpecAsync send a type specification request without waiting for the response, see GetTypeSpec. The result is
+// not cached.
+func (client *IedClient) GetTypeSpecAsync(objectRef string, constraint FunctionalConstraint, timeout time.Duration, handler func(AsyncResult)) (*AsyncCall, error) {
+	if err := client.acquire(); err != nil {
+		return nil, err
+	}
+	defer client.release()
+
+	cObjectRef := C.CString(objectRef)
+	defer C.free(unsafe.Pointer(cObjectRef))
+
+	call := client.newAsyncCall("get variable specification", objectRef, handler)
+
+	var clientError C.IedClientError
+	var invokeId C.uint32_t
+	client.sendWithTimeout(timeout, func() {
+		invokeId = C.getVariableSpecificationAsync(client.connection, &clientError, cObjectRef, C.FunctionalConstraint(constraint), C.uintptr_t(call.id))
+	})
+
+	if clientError != C.IED_ERROR_OK {
+		return nil, call.abort(clientError)
+	}
+
+	call.start(uint32(invokeId), timeout)
+
+	return call, nil
+}
+
+// GetDataSetDirectoryAsync send a data set directory request without waiting for the response, see GetDataSetDirectory
+func (client *IedClient) GetDataSetDirectoryAsync(dataSetReference string, timeout time.Duration, handler func(AsyncResult)) (*AsyncCall, error) {
+	if err := client.acquire(); err != nil {
+		return nil, err
+	}
+	defer client.release()
+
+	cDataSetReference := C.CString(dataSetReference)
+	defer C.free(unsafe.Pointer(cDataSetReference))
+
+	call := client.newAsyncCall("get dataset directory", dataSetReference, handler)
+
+	var clientError C.IedClientError
+	var invokeId C.uint32_t
+	client.sendWithTimeout(timeout, func() {
+		invokeId = C.getDataSetDirectoryAsync(client.connection, &clientError, cDataSetReference, C.uintptr_t(call.id))
+	})
+
+	if clientError != C.IED_ERROR_OK {
+		return nil, call.abort(clientError)
+	}
+
+	call.start(uint32(invokeId), timeout)
+
+	return call, nil
+}
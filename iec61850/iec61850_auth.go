@@ -0,0 +1,305 @@
+package iec61850
+
+/*
+#include <stdint.h>
+#include <stdlib.h>
+#include <iec61850_client.h>
+#include <iec61850_server.h>
+
+extern bool goAuthenticator(uintptr_t id, int mechanism, uint8_t* value, int valueLength, uint16_t* apTitle, int apTitleLength, int aeQualifier, uintptr_t slot);
+extern void goConnectionIndicationHandler(IedServer self, ClientConnection connection, bool connected, void* parameter);
+
+static bool authenticator(void* parameter, AcseAuthenticationParameter authParameter, void** securityToken, IsoApplicationReference* appReference)
+{
+	uint8_t* value = NULL;
+	int valueLength = 0;
+
+	if (authParameter->mechanism == ACSE_AUTH_PASSWORD) {
+		value = authParameter->value.password.octetString;
+		valueLength = authParameter->value.password.passwordLength;
+	}
+	else if (authParameter->mechanism == ACSE_AUTH_CERTIFICATE || authParameter->mechanism == ACSE_AUTH_TLS) {
+		value = authParameter->value.certificate.buf;
+		valueLength = authParameter->value.certificate.length;
+	}
+
+	uint16_t* apTitle = NULL;
+	int apTitleLength = 0;
+	int aeQualifier = 0;
+
+	if (appReference != NULL) {
+		apTitle = appReference->apTitle.arc;
+		apTitleLength = appReference->apTitle.arcCount;
+		aeQualifier = appReference->aeQualifier;
+	}
+
+	// the authenticator does not get the connection, while the client is authenticated the security token of its
+	// connection is the address of the token, the Go side finds the connection by it among the open connections
+	*securityToken = (void*) securityToken;
+
+	bool accept = goAuthenticator((uintptr_t) parameter, authParameter->mechanism, value, valueLength, apTitle, apTitleLength, aeQualifier, (uintptr_t) securityToken);
+
+	if (!accept)
+		*securityToken = NULL;
+
+	return accept;
+}
+
+static void setAuthenticator(IedServer self, uintptr_t id)
+{
+	IedServer_setAuthenticator(self, authenticator, (void*) id);
+}
+
+static void setConnectionIndicationHandler(IedServer self, uintptr_t id)
+{
+	IedServer_setConnectionIndicationHandler(self, goConnectionIndicationHandler, (void*) id);
+}
+
+static uintptr_t ClientConnection_getSecurityTokenId(ClientConnection self)
+{
+	return (uintptr_t) ClientConnection_getSecurityToken(self);
+}
+*/
+import "C"
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"unsafe"
+)
+
+// AuthMechanism the ACSE authentication mechanism, see AcseAuthenticationMechanism
+type AuthMechanism int
+
+const (
+	ACSE_AUTH_NONE AuthMechanism = iota
+	ACSE_AUTH_PASSWORD
+	ACSE_AUTH_CERTIFICATE
+	ACSE_AUTH_TLS
+)
+
+// Credential the authentication presented by a client, Value is the password or the certificate depending on
+// Mechanism. ApTitle (e.g. "1.1.1.999") and AeQualifier identify the calling application, PeerAddress the address
+// the client connects from.
+type Credential struct {
+	Mechanism   AuthMechanism
+	Value       []byte
+	ApTitle     string
+	AeQualifier int
+	PeerAddress string
+}
+
+// Authenticator decide whether a client is accepted. The security token of an accepted client is available to the
+// connection and access handlers as ClientConnection.SecurityToken.
+type Authenticator func(credential Credential) (accept bool, securityToken interface{})
+
+// ClientConnection a client connected to the server
+type ClientConnection struct {
+	PeerAddress   string
+	LocalAddress  string
+	SecurityToken interface{}
+}
+
+// AcsePassword authenticate the client by an ACSE password when connecting
+func AcsePassword(password string) func(*IedClient) {
+	return func(c *IedClient) {
+		cPassword := C.CString(password)
+		defer C.free(unsafe.Pointer(cPassword))
+
+		if c.authParameter == nil {
+			c.authParameter = C.AcseAuthenticationParameter_create()
+		}
+
+		// the password is copied by libiec61850
+		C.AcseAuthenticationParameter_setAuthMechanism(c.authParameter, C.ACSE_AUTH_PASSWORD)
+		C.AcseAuthenticationParameter_setPassword(c.authParameter, cPassword)
+
+		parameters := C.MmsConnection_getIsoConnectionParameters(C.IedConnection_getMmsConnection(c.connection))
+		C.IsoConnectionParameters_setAcseAuthenticationParameter(parameters, c.authParameter)
+	}
+}
+
+// serverConnection a connection opened by a client. libiec61850 indicates a connection before the client is
+// authenticated, so the connection handlers of a server with an Authenticator are only called once it is accepted.
+type serverConnection struct {
+	connection ClientConnection
+	announced  bool
+}
+
+// serverCallbacks the Go callbacks of a server, the id is passed to the C callbacks to find them again
+type serverCallbacks struct {
+	id uintptr
+
+	mutex           sync.Mutex
+	authenticator   Authenticator
+	handlers        []func(ClientConnection, bool)
+	connections     map[C.ClientConnection]*serverConnection
+	readHandler     func(ClientConnection, string, FunctionalConstraint) bool
+	writeHandlers   map[*C.DataAttribute]func(ClientConnection, MmsValue) bool
+	controlHandlers map[*C.DataObject]func(ClientConnection, ControlCheck) bool
+}
+
+var (
+	serverCallbacksMutex sync.Mutex
+	serversCallbacks     = make(map[uintptr]*serverCallbacks)
+	serverCallbacksId    uintptr
+)
+
+func lookupServerCallbacks(id uintptr) *serverCallbacks {
+	serverCallbacksMutex.Lock()
+	defer serverCallbacksMutex.Unlock()
+
+	return serversCallbacks[id]
+}
+
+// getCallbacks register the callbacks of the server on first use, the connection indication handler keeps track of
+// the open connections for the authenticator and the access handlers
+func (is *IedServer) getCallbacks() *serverCallbacks {
+	if is.callbacks != nil {
+		return is.callbacks
+	}
+
+	callbacks := &serverCallbacks{
+		connections:     make(map[C.ClientConnection]*serverConnection),
+		writeHandlers:   make(map[*C.DataAttribute]func(ClientConnection, MmsValue) bool),
+		controlHandlers: make(map[*C.DataObject]func(ClientConnection, ControlCheck) bool),
+	}
+
+	serverCallbacksMutex.Lock()
+	serverCallbacksId++
+	callbacks.id = serverCallbacksId
+	serversCallbacks[callbacks.id] = callbacks
+	serverCallbacksMutex.Unlock()
+
+	C.setConnectionIndicationHandler(is.server, C.uintptr_t(callbacks.id))
+
+	is.callbacks = callbacks
+	return callbacks
+}
+
+// SetAuthenticator check the ACSE authentication of every client, has to be set before Start
+func (is *IedServer) SetAuthenticator(authenticator Authenticator) {
+	callbacks := is.getCallbacks()
+
+	callbacks.mutex.Lock()
+	callbacks.authenticator = authenticator
+	callbacks.mutex.Unlock()
+
+	C.setAuthenticator(is.server, C.uintptr_t(callbacks.id))
+}
+
+// OnConnection call handler when a client connects (connected is true) or disconnects. With an Authenticator only
+// the accepted clients are indicated.
+func (is *IedServer) OnConnection(handler func(connection ClientConnection, connected bool)) {
+	callbacks := is.getCallbacks()
+
+	callbacks.mutex.Lock()
+	callbacks.handlers = append(callbacks.handlers, handler)
+	callbacks.mutex.Unlock()
+}
+
+// authenticate the client of the connection whose security token is slot, see the C authenticator
+func (callbacks *serverCallbacks) authenticate(slot uintptr, credential Credential) bool {
+	callbacks.mutex.Lock()
+	authenticator := callbacks.authenticator
+	var opened *serverConnection
+	for connection, candidate := range callbacks.connections {
+		if uintptr(C.ClientConnection_getSecurityTokenId(connection)) == slot {
+			opened = candidate
+			break
+		}
+	}
+	callbacks.mutex.Unlock()
+
+	if authenticator == nil {
+		return true
+	}
+
+	if opened != nil {
+		credential.PeerAddress = opened.connection.PeerAddress
+	}
+
+	accept, token := authenticator(credential)
+	if !accept || opened == nil {
+		return accept
+	}
+
+	callbacks.mutex.Lock()
+	opened.connection.SecurityToken = token
+	opened.announced = true
+	connection := opened.connection
+	handlers := callbacks.handlers
+	callbacks.mutex.Unlock()
+
+	for _, handler := range handlers {
+		handler(connection, true)
+	}
+
+	return true
+}
+
+// stopped forget the connections, libiec61850 does not indicate the connections it closes when the server is stopped
+func (callbacks *serverCallbacks) stopped() {
+	callbacks.mutex.Lock()
+	defer callbacks.mutex.Unlock()
+
+	callbacks.connections = make(map[C.ClientConnection]*serverConnection)
+}
+
+func (callbacks *serverCallbacks) connectionIndication(connection C.ClientConnection, connected bool) {
+	callbacks.mutex.Lock()
+	opened, ok := callbacks.connections[connection]
+	if connected {
+		opened = &serverConnection{
+			connection: ClientConnection{
+				PeerAddress:  cStringToGoString(C.ClientConnection_getPeerAddress(connection)),
+				LocalAddress: cStringToGoString(C.ClientConnection_getLocalAddress(connection)),
+			},
+			announced: callbacks.authenticator == nil,
+		}
+		callbacks.connections[connection] = opened
+		ok = true
+	} else {
+		delete(callbacks.connections, connection)
+	}
+	handlers := callbacks.handlers
+	callbacks.mutex.Unlock()
+
+	if !ok || !opened.announced {
+		return
+	}
+
+	for _, handler := range handlers {
+		handler(opened.connection, connected)
+	}
+}
+
+// lookupConnection the connection of an access, the SecurityToken is set once the client is accepted
+func (callbacks *serverCallbacks) lookupConnection(connection C.ClientConnection) ClientConnection {
+	callbacks.mutex.Lock()
+	defer callbacks.mutex.Unlock()
+
+	if opened, ok := callbacks.connections[connection]; ok {
+		return opened.connection
+	}
+
+	return ClientConnection{
+		PeerAddress:  cStringToGoString(C.ClientConnection_getPeerAddress(connection)),
+		LocalAddress: cStringToGoString(C.ClientConnection_getLocalAddress(connection)),
+	}
+}
+
+// unregister drop the callbacks when the server is destroyed
+func (callbacks *serverCallbacks) unregister() {
+	serverCallbacksMutex.Lock()
+	delete(serversCallbacks, callbacks.id)
+	serverCallbacksMutex.Unlock()
+}
+
+func formatApTitle(arcs []uint16) string {
+	parts := make([]string, len(arcs))
+	for i, arc := range arcs {
+		parts[i] = strconv.Itoa(int(arc))
+	}
+	return strings.Join(parts, ".")
+}
@@ -0,0 +1,241 @@
+package iec61850
+
+// this file only contains the Go functions called by libiec61850, a cgo file with //export must not define C functions
+
+// #include <iec61850_client.h>
+// #include <iec61850_server.h>
+import "C"
+import (
+	"fmt"
+	"unsafe"
+)
+
+//export goReadObjectHandler
+func goReadObjectHandler(invokeId C.uint32_t, parameter unsafe.Pointer, clientError C.IedClientError, value *C.MmsValue) {
+	if value != nil {
+		defer C.MmsValue_delete(value)
+	}
+
+	call := takeAsyncCall(uintptr(parameter))
+	if call == nil {
+		return
+	}
+
+	result := AsyncResult{InvokeId: uint32(invokeId)}
+	if clientError != C.IED_ERROR_OK {
+		result.Err = call.error(clientError)
+	} else {
+		result.Value = call.client.decodeNamed(call.objectRef, call.client.toMmsValue(value))
+	}
+
+	call.complete(result)
+}
+
+//export goGenericServiceHandler
+func goGenericServiceHandler(invokeId C.uint32_t, parameter unsafe.Pointer, clientError C.IedClientError) {
+	call := takeAsyncCall(uintptr(parameter))
+	if call == nil {
+		return
+	}
+
+	result := AsyncResult{InvokeId: uint32(invokeId)}
+	if clientError != C.IED_ERROR_OK {
+		result.Err = call.error(clientError)
+	}
+
+	call.complete(result)
+}
+
+//export goReadDataSetHandler
+func goReadDataSetHandler(invokeId C.uint32_t, parameter unsafe.Pointer, clientError C.IedClientError, dataSet C.ClientDataSet) {
+	if dataSet != nil {
+		defer C.ClientDataSet_destroy(dataSet)
+	}
+
+	call := takeAsyncCall(uintptr(parameter))
+	if call == nil {
+		return
+	}
+
+	result := AsyncResult{InvokeId: uint32(invokeId)}
+	if clientError != C.IED_ERROR_OK {
+		result.Err = call.error(clientError)
+	} else {
+		values := C.ClientDataSet_getValues(dataSet)
+		size := int(C.ClientDataSet_getDataSetSize(dataSet))
+		result.Values = make([]MmsValue, size)
+		for i := 0; i < size; i++ {
+			result.Values[i] = call.client.toMmsValue(C.MmsValue_getElement(values, C.int(i)))
+		}
+	}
+
+	call.complete(result)
+}
+
+//export goVariableSpecHandler
+func goVariableSpecHandler(invokeId C.uint32_t, parameter unsafe.Pointer, clientError C.IedClientError, spec *C.MmsVariableSpecification) {
+	if spec != nil {
+		defer C.MmsVariableSpecification_destroy(spec)
+	}
+
+	call := takeAsyncCall(uintptr(parameter))
+	if call == nil {
+		return
+	}
+
+	result := AsyncResult{InvokeId: uint32(invokeId)}
+	if clientError != C.IED_ERROR_OK {
+		result.Err = call.error(clientError)
+	} else {
+		result.Spec = newVariableSpec(spec)
+	}
+
+	call.complete(result)
+}
+
+//export goDataSetDirectoryHandler
+func goDataSetDirectoryHandler(invokeId C.uint32_t, parameter unsafe.Pointer, clientError C.IedClientError, members C.LinkedList, deletable C.bool) {
+	if members != nil {
+		defer C.LinkedList_destroy(members)
+	}
+
+	call := takeAsyncCall(uintptr(parameter))
+	if call == nil {
+		return
+	}
+
+	result := AsyncResult{InvokeId: uint32(invokeId)}
+	if clientError != C.IED_ERROR_OK {
+		result.Err = call.error(clientError)
+	} else if directory, err := newDataSetDirectory(members, deletable); err != nil {
+		result.Err = fmt.Errorf("failed to %s %s, %v", call.operation, call.objectRef, err)
+	} else {
+		result.Directory = directory
+	}
+
+	call.complete(result)
+}
+
+//export goControlActionHandler
+func goControlActionHandler(invokeId C.uint32_t, parameter unsafe.Pointer, clientError C.IedClientError, actionType C.ControlActionType, success C.bool) {
+	call := takeAsyncCall(uintptr(parameter))
+	if call == nil {
+		return
+	}
+
+	result := AsyncResult{InvokeId: uint32(invokeId)}
+	if clientError != C.IED_ERROR_OK {
+		result.Err = call.error(clientError)
+	} else if !bool(success) {
+		result.Err = fmt.Errorf("failed to %s %s", call.operation, call.objectRef)
+	}
+
+	call.complete(result)
+}
+
+//export goCommandTerminationHandler
+func goCommandTerminationHandler(parameter unsafe.Pointer, control C.ControlObjectClient) {
+	object := lookupControlObject(uintptr(parameter))
+	if object == nil {
+		return
+	}
+
+	object.terminated(lastApplError(control))
+}
+
+//export goStateChangedHandler
+func goStateChangedHandler(parameter unsafe.Pointer, connection C.IedConnection, newState C.IedConnectionState) {
+	notifier := lookupStateNotifier(uintptr(parameter))
+	if notifier == nil {
+		return
+	}
+
+	notifier.changed(ClientState(newState))
+}
+
+//export goReportHandler
+func goReportHandler(parameter unsafe.Pointer, report C.ClientReport) {
+	subscription := lookupReportSubscription(uintptr(parameter))
+	if subscription == nil {
+		return
+	}
+
+	subscription.received(subscription.newReport(report))
+}
+
+//export goAuthenticator
+func goAuthenticator(id C.uintptr_t, mechanism C.int, value *C.uint8_t, valueLength C.int, apTitle *C.uint16_t, apTitleLength C.int, aeQualifier C.int, slot C.uintptr_t) C.bool {
+	callbacks := lookupServerCallbacks(uintptr(id))
+	if callbacks == nil {
+		return false
+	}
+
+	credential := Credential{
+		Mechanism:   AuthMechanism(mechanism),
+		AeQualifier: int(aeQualifier),
+	}
+	if value != nil && valueLength > 0 {
+		credential.Value = C.GoBytes(unsafe.Pointer(value), valueLength)
+	}
+	if apTitle != nil && apTitleLength > 0 {
+		credential.ApTitle = formatApTitle(unsafe.Slice((*uint16)(unsafe.Pointer(apTitle)), int(apTitleLength)))
+	}
+
+	return C.bool(callbacks.authenticate(uintptr(slot), credential))
+}
+
+//export goConnectionIndicationHandler
+func goConnectionIndicationHandler(server C.IedServer, connection C.ClientConnection, connected C.bool, parameter unsafe.Pointer) {
+	callbacks := lookupServerCallbacks(uintptr(parameter))
+	if callbacks == nil {
+		return
+	}
+
+	callbacks.connectionIndication(connection, bool(connected))
+}
+
+//export goReadAccessHandler
+func goReadAccessHandler(ld *C.LogicalDevice, ln *C.LogicalNode, dataObject *C.DataObject, constraint C.FunctionalConstraint, connection C.ClientConnection, parameter unsafe.Pointer) C.MmsDataAccessError {
+	callbacks := lookupServerCallbacks(uintptr(parameter))
+	if callbacks == nil {
+		return C.DATA_ACCESS_ERROR_SUCCESS
+	}
+
+	node := (*C.ModelNode)(unsafe.Pointer(ld))
+	if dataObject != nil {
+		node = (*C.ModelNode)(unsafe.Pointer(dataObject))
+	} else if ln != nil {
+		node = (*C.ModelNode)(unsafe.Pointer(ln))
+	}
+
+	if !callbacks.checkRead(connection, node, FunctionalConstraint(constraint)) {
+		return C.DATA_ACCESS_ERROR_OBJECT_ACCESS_DENIED
+	}
+	return C.DATA_ACCESS_ERROR_SUCCESS
+}
+
+//export goWriteAccessHandler
+func goWriteAccessHandler(dataAttribute *C.DataAttribute, value *C.MmsValue, connection C.ClientConnection, parameter unsafe.Pointer) C.MmsDataAccessError {
+	callbacks := lookupServerCallbacks(uintptr(parameter))
+	if callbacks == nil {
+		return C.DATA_ACCESS_ERROR_SUCCESS
+	}
+
+	if !callbacks.checkWrite(connection, dataAttribute, value) {
+		return C.DATA_ACCESS_ERROR_OBJECT_ACCESS_DENIED
+	}
+	return C.DATA_ACCESS_ERROR_SUCCESS
+}
+
+//export goControlCheckHandler
+func goControlCheckHandler(action C.ControlAction, parameter unsafe.Pointer, ctlVal *C.MmsValue, test C.bool, interlockCheck C.bool) C.CheckHandlerResult {
+	callbacks := lookupServerCallbacks(uintptr(parameter))
+	if callbacks == nil {
+		return C.CONTROL_ACCEPTED
+	}
+
+	if !callbacks.checkControl(action, ctlVal, bool(test), bool(interlockCheck)) {
+		return C.CONTROL_OBJECT_ACCESS_DENIED
+	}
+	return C.CONTROL_ACCEPTED
+}
@@ -0,0 +1,261 @@
+package iec61850
+
+import (
+	"fmt"
+)
+
+// Dbpos the double point position of DPS/DPC stVal
+type Dbpos int
+
+const (
+	DBPOS_INTERMEDIATE_STATE Dbpos = iota
+	DBPOS_OFF
+	DBPOS_ON
+	DBPOS_BAD_STATE
+)
+
+// MV Measured value
+type MV struct {
+	Mag float64
+	Q   Quality
+	T   Timestamp
+}
+
+// SPS Single point status
+type SPS struct {
+	StVal bool
+	Q     Quality
+	T     Timestamp
+}
+
+// DPS Double point status
+type DPS struct {
+	StVal Dbpos
+	Q     Quality
+	T     Timestamp
+}
+
+// INS Integer status
+type INS struct {
+	StVal int32
+	Q     Quality
+	T     Timestamp
+}
+
+// CMV Complex measured value
+type CMV struct {
+	Mag float64
+	Ang float64
+	Q   Quality
+	T   Timestamp
+}
+
+// WYE Phase to ground/neutral related measured values of a three-phase system
+type WYE struct {
+	PhsA CMV
+	PhsB CMV
+	PhsC CMV
+	Neut CMV
+	Net  CMV
+	Res  CMV
+}
+
+// ReadMV read mag, q and t of a MV data object in one request
+func (client *IedClient) ReadMV(objectRef string) (MV, error) {
+	values, err := client.readFlattened(objectRef, IEC61850_FC_MX)
+	if err != nil {
+		return MV{}, err
+	}
+
+	mag, ok := analogueValue(values, "mag")
+	if !ok {
+		return MV{}, fmt.Errorf("failed to read object %s, mag not found", objectRef)
+	}
+
+	return MV{
+		Mag: mag,
+		Q:   toQuality(values["q"]),
+		T:   toTimestamp(values["t"]),
+	}, nil
+}
+
+// ReadSPS read stVal, q and t of a SPS data object in one request
+func (client *IedClient) ReadSPS(objectRef string) (SPS, error) {
+	values, err := client.readFlattened(objectRef, IEC61850_FC_ST)
+	if err != nil {
+		return SPS{}, err
+	}
+
+	stVal, ok := values["stVal"].(Boolean)
+	if !ok {
+		return SPS{}, fmt.Errorf("failed to read object %s, unexpected stVal: %v", objectRef, values["stVal"])
+	}
+
+	return SPS{
+		StVal: bool(stVal),
+		Q:     toQuality(values["q"]),
+		T:     toTimestamp(values["t"]),
+	}, nil
+}
+
+// ReadDPS read stVal, q and t of a DPS data object in one request
+func (client *IedClient) ReadDPS(objectRef string) (DPS, error) {
+	values, err := client.readFlattened(objectRef, IEC61850_FC_ST)
+	if err != nil {
+		return DPS{}, err
+	}
+
+	stVal, ok := values["stVal"].(BitString)
+	if !ok || stVal.Size != 2 {
+		return DPS{}, fmt.Errorf("failed to read object %s, unexpected stVal: %v", objectRef, values["stVal"])
+	}
+
+	// the first bit of the bit string is the high bit: 01 = off, 10 = on
+	dbpos := DBPOS_INTERMEDIATE_STATE
+	if stVal.Bit(0) {
+		dbpos |= 2
+	}
+	if stVal.Bit(1) {
+		dbpos |= 1
+	}
+
+	return DPS{
+		StVal: dbpos,
+		Q:     toQuality(values["q"]),
+		T:     toTimestamp(values["t"]),
+	}, nil
+}
+
+// ReadINS read stVal, q and t of an INS data object in one request
+func (client *IedClient) ReadINS(objectRef string) (INS, error) {
+	values, err := client.readFlattened(objectRef, IEC61850_FC_ST)
+	if err != nil {
+		return INS{}, err
+	}
+
+	stVal, ok := values["stVal"].(Integer)
+	if !ok {
+		return INS{}, fmt.Errorf("failed to read object %s, unexpected stVal: %v", objectRef, values["stVal"])
+	}
+
+	return INS{
+		StVal: int32(stVal),
+		Q:     toQuality(values["q"]),
+		T:     toTimestamp(values["t"]),
+	}, nil
+}
+
+// ReadCMV read cVal, q and t of a CMV data object in one request
+func (client *IedClient) ReadCMV(objectRef string) (CMV, error) {
+	values, err := client.readFlattened(objectRef, IEC61850_FC_MX)
+	if err != nil {
+		return CMV{}, err
+	}
+
+	cmv, ok := complexValue(values, "")
+	if !ok {
+		return CMV{}, fmt.Errorf("failed to read object %s, cVal not found", objectRef)
+	}
+
+	return cmv, nil
+}
+
+// ReadWYE read all phases of a WYE data object in one request, phases missing on the server are left empty
+func (client *IedClient) ReadWYE(objectRef string) (WYE, error) {
+	values, err := client.readFlattened(objectRef, IEC61850_FC_MX)
+	if err != nil {
+		return WYE{}, err
+	}
+
+	var wye WYE
+	found := false
+	for name, target := range map[string]*CMV{
+		"phsA": &wye.PhsA,
+		"phsB": &wye.PhsB,
+		"phsC": &wye.PhsC,
+		"neut": &wye.Neut,
+		"net":  &wye.Net,
+		"res":  &wye.Res,
+	} {
+		if cmv, ok := complexValue(values, name); ok {
+			*target = cmv
+			found = true
+		}
+	}
+
+	if !found {
+		return WYE{}, fmt.Errorf("failed to read object %s, no phase found", objectRef)
+	}
+
+	return wye, nil
+}
+
+// analogueValue the AnalogueValue at path, either the float (f) or the integer (i) component
+func analogueValue(values map[string]MmsValue, path string) (float64, bool) {
+	if f, ok := toFloat64(values[joinPath(path, "f")]); ok {
+		return f, true
+	}
+	return toFloat64(values[joinPath(path, "i")])
+}
+
+func complexValue(values map[string]MmsValue, path string) (CMV, bool) {
+	mag, ok := analogueValue(values, joinPath(path, "cVal.mag"))
+	if !ok {
+		return CMV{}, false
+	}
+
+	// ang is optional
+	ang, _ := analogueValue(values, joinPath(path, "cVal.ang"))
+
+	return CMV{
+		Mag: mag,
+		Ang: ang,
+		Q:   toQuality(values[joinPath(path, "q")]),
+		T:   toTimestamp(values[joinPath(path, "t")]),
+	}, true
+}
+
+func toFloat64(value MmsValue) (float64, bool) {
+	switch v := value.(type) {
+	case Float32:
+		return float64(v), true
+	case Float64:
+		return float64(v), true
+	case Integer:
+		return float64(v), true
+	case Unsigned:
+		return float64(v), true
+	}
+	return 0, false
+}
+
+// toQuality accept the decoded and the raw form (WithoutTimestamps). A missing q or a value which is not a quality is
+// invalid, so it is never taken for good data.
+func toQuality(value MmsValue) Quality {
+	if q, ok := qualityValue(value); ok {
+		return q
+	}
+	return Quality(QUALITY_VALIDITY_INVALID)
+}
+
+// qualityValue the quality of a decoded q or of the raw 13 or 14 bit string
+func qualityValue(value MmsValue) (Quality, bool) {
+	switch v := value.(type) {
+	case Quality:
+		return v, true
+	case BitString:
+		return qualityFromBitString(v)
+	}
+	return 0, false
+}
+
+// toTimestamp accept the decoded and the raw form (WithoutTimestamps)
+func toTimestamp(value MmsValue) Timestamp {
+	switch v := value.(type) {
+	case Timestamp:
+		return v
+	case UtcTime:
+		return v.Timestamp()
+	}
+	return Timestamp{}
+}
@@ -7,8 +7,10 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"runtime"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 	"unsafe"
 
@@ -24,23 +26,59 @@ const (
 	IED_STATE_CLOSING
 )
 
-type GoMmsValue struct {
-	Type  MMSType     // MMS_VALUE ENUM
-	Value interface{} // The Go representation of the value
-}
-
 type Option func(client *IedClient)
 
 type IedClient struct {
-	withoutTimestamps bool
-
-	connection C.IedConnection
+	withoutTimestamps   bool
+	maxVariablesPerRead int
+	connectTimeout      time.Duration
+	// optionErr an invalid option, returned by Connect
+	optionErr error
+
+	connection       C.IedConnection
+	tlsConfiguration C.TLSConfiguration
+	authParameter    C.AcseAuthenticationParameter
+	notifier         *stateNotifier
+
+	// closeMutex guards closed, inFlight counts the calls using the connection, Close waits for them before destroy
+	closeMutex sync.Mutex
+	closed     bool
+	inFlight   sync.WaitGroup
+	closeOnce  sync.Once
+
+	// specCache the type specifications read from the IED, specRejected the members ExplainDataSetComponents could not
+	// get a specification for, both are cleared on connect and close
+	specMutex    sync.Mutex
+	specCache    map[string]*MmsVariableSpecification
+	specRejected map[string]error
+
+	reportsMutex sync.Mutex
+	reports      map[string]*reportSubscription
+
+	controlsMutex sync.Mutex
+	controls      map[*ControlObject]struct{}
+
+	// requestTimeoutMutex serializes the asynchronous requests sent with a shorter RequestTimeout
+	requestTimeoutMutex sync.Mutex
+
+	// peer the "hostname:port" of the last connect
+	peerMutex sync.Mutex
+	peer      string
 }
 
 func NewIedClient(options ...Option) *IedClient {
+	return newIedClient(C.IedConnection_create(), options)
+}
+
+func newIedClient(connection C.IedConnection, options []Option) *IedClient {
 	client := &IedClient{
-		connection: C.IedConnection_create(),
+		connection:     connection,
+		connectTimeout: defaultConnectTimeout,
 	}
+	client.notifier = newStateNotifier(client.connection)
+
+	// a client dropped without Close still releases its connection
+	runtime.SetFinalizer(client, (*IedClient).Close)
 
 	for _, op := range options {
 		if op != nil {
@@ -51,11 +89,15 @@ func NewIedClient(options ...Option) *IedClient {
 	return client
 }
 
+// defaultConnectTimeout CONFIG_MMS_CONNECTION_DEFAULT_CONNECT_TIMEOUT of libiec61850
+const defaultConnectTimeout = 10 * time.Second
+
 func ConnectTimeout(timeout time.Duration) func(*IedClient) {
 	// #define CONFIG_MMS_CONNECTION_DEFAULT_CONNECT_TIMEOUT 10000
 	return func(c *IedClient) {
 		// replace to c time
 		C.IedConnection_setConnectTimeout(c.connection, C.uint(timeout/1e6))
+		c.connectTimeout = timeout
 	}
 }
 
@@ -66,6 +108,10 @@ func RequestTimeout(timeout time.Duration) func(*IedClient) {
 	}
 }
 
+// WithoutTimestamps decode q and t attributes to the raw BitString and UtcTime values instead of Quality and Timestamp.
+// A q is only decoded to Quality where its name is known: the read of the q itself, the components named by a type
+// specification or the SCL, and reports with data references. In a structure or a data set read as a whole it is a
+// BitString, use the CDC readers, ReadInto or ExplainDataSetValues to get its Quality.
 func WithoutTimestamps(flag bool) func(*IedClient) {
 	return func(c *IedClient) {
 		c.withoutTimestamps = flag
@@ -73,25 +119,62 @@ func WithoutTimestamps(flag bool) func(*IedClient) {
 }
 
 func (client *IedClient) Connect(hostname string, tcpPort int) error {
+	if err := client.acquire(); err != nil {
+		return err
+	}
+	defer client.release()
+
+	if client.optionErr != nil {
+		return client.optionErr
+	}
+
 	cHostname := C.CString(hostname)
 	defer C.free(unsafe.Pointer(cHostname))
 
+	client.notifier.requestClose(false)
+	client.setPeerAddress(hostname, tcpPort)
+	client.clearSpecCache()
+
 	var clientError C.IedClientError
 	C.IedConnection_connect(client.connection, &clientError, cHostname, C.int(tcpPort))
 	if clientError == C.IED_ERROR_ALREADY_CONNECTED {
 		return nil
 	} else if clientError != C.IED_ERROR_OK {
-		return fmt.Errorf("failed to connect to %s:%d, clientError: %v", hostname, tcpPort, Err(clientError))
+		return newClientError("connect to", fmt.Sprintf("%s:%d", hostname, tcpPort), clientError)
 	}
 	return nil
 }
 
+func (client *IedClient) setPeerAddress(hostname string, tcpPort int) {
+	client.peerMutex.Lock()
+	client.peer = fmt.Sprintf("%s:%d", hostname, tcpPort)
+	client.peerMutex.Unlock()
+}
+
+// peerAddress the "hostname:port" of the last connect, empty if the client was never connected
+func (client *IedClient) peerAddress() string {
+	client.peerMutex.Lock()
+	defer client.peerMutex.Unlock()
+
+	return client.peer
+}
+
 func (client *IedClient) State() ClientState {
+	if err := client.acquire(); err != nil {
+		return IED_STATE_CLOSED
+	}
+	defer client.release()
+
 	state := C.IedConnection_getState(client.connection)
 	return ClientState(state)
 }
 
 func (client *IedClient) ReadBoolean(objectRef string, constraint FunctionalConstraint) (bool, error) {
+	if err := client.acquire(); err != nil {
+		return false, err
+	}
+	defer client.release()
+
 	cObjectRef := C.CString(objectRef)
 	defer C.free(unsafe.Pointer(cObjectRef))
 
@@ -99,13 +182,18 @@ func (client *IedClient) ReadBoolean(objectRef string, constraint FunctionalCons
 	value := C.IedConnection_readBooleanValue(client.connection, &clientError, cObjectRef, C.FunctionalConstraint(constraint))
 
 	if clientError != C.IED_ERROR_OK {
-		return false, fmt.Errorf("failed to read object %s, clientError: %v", objectRef, Err(clientError))
+		return false, newClientError("read object", objectRef, clientError)
 	}
 
 	return bool(value), nil
 }
 
 func (client *IedClient) ReadFloat(objectRef string, constraint FunctionalConstraint) (float64, error) {
+	if err := client.acquire(); err != nil {
+		return float64(0), err
+	}
+	defer client.release()
+
 	cObjectRef := C.CString(objectRef)
 	defer C.free(unsafe.Pointer(cObjectRef))
 
@@ -113,13 +201,18 @@ func (client *IedClient) ReadFloat(objectRef string, constraint FunctionalConstr
 	value := C.IedConnection_readFloatValue(client.connection, &clientError, cObjectRef, C.FunctionalConstraint(constraint))
 
 	if clientError != C.IED_ERROR_OK {
-		return float64(0), fmt.Errorf("failed to read object %s, clientError: %v", objectRef, Err(clientError))
+		return float64(0), newClientError("read object", objectRef, clientError)
 	}
 
 	return float64(value), nil
 }
 
 func (client *IedClient) ReadString(objectRef string, constraint FunctionalConstraint) (string, error) {
+	if err := client.acquire(); err != nil {
+		return "", err
+	}
+	defer client.release()
+
 	cObjectRef := C.CString(objectRef)
 	defer C.free(unsafe.Pointer(cObjectRef))
 
@@ -127,13 +220,18 @@ func (client *IedClient) ReadString(objectRef string, constraint FunctionalConst
 	value := C.IedConnection_readStringValue(client.connection, &clientError, cObjectRef, C.FunctionalConstraint(constraint))
 
 	if clientError != C.IED_ERROR_OK {
-		return "", fmt.Errorf("failed to read object %s, clientError: %v", objectRef, Err(clientError))
+		return "", newClientError("read object", objectRef, clientError)
 	}
 
 	return C.GoString(value), nil
 }
 
 func (client *IedClient) ReadInt32(objectRef string, constraint FunctionalConstraint) (int32, error) {
+	if err := client.acquire(); err != nil {
+		return int32(0), err
+	}
+	defer client.release()
+
 	cObjectRef := C.CString(objectRef)
 	defer C.free(unsafe.Pointer(cObjectRef))
 
@@ -141,13 +239,18 @@ func (client *IedClient) ReadInt32(objectRef string, constraint FunctionalConstr
 	value := C.IedConnection_readInt32Value(client.connection, &clientError, cObjectRef, C.FunctionalConstraint(constraint))
 
 	if clientError != C.IED_ERROR_OK {
-		return int32(0), fmt.Errorf("failed to read object %s, clientError: %v", objectRef, Err(clientError))
+		return int32(0), newClientError("read object", objectRef, clientError)
 	}
 
 	return int32(value), nil
 }
 
 func (client *IedClient) ReadInt64(objectRef string, constraint FunctionalConstraint) (int64, error) {
+	if err := client.acquire(); err != nil {
+		return int64(0), err
+	}
+	defer client.release()
+
 	cObjectRef := C.CString(objectRef)
 	defer C.free(unsafe.Pointer(cObjectRef))
 
@@ -155,13 +258,18 @@ func (client *IedClient) ReadInt64(objectRef string, constraint FunctionalConstr
 	value := C.IedConnection_readInt64Value(client.connection, &clientError, cObjectRef, C.FunctionalConstraint(constraint))
 
 	if clientError != C.IED_ERROR_OK {
-		return int64(0), fmt.Errorf("failed to read object %s, clientError: %v", objectRef, Err(clientError))
+		return int64(0), newClientError("read object", objectRef, clientError)
 	}
 
 	return int64(value), nil
 }
 
 func (client *IedClient) ReadUnsigned32(objectRef string, constraint FunctionalConstraint) (uint32, error) {
+	if err := client.acquire(); err != nil {
+		return uint32(0), err
+	}
+	defer client.release()
+
 	cObjectRef := C.CString(objectRef)
 	defer C.free(unsafe.Pointer(cObjectRef))
 
@@ -169,97 +277,229 @@ func (client *IedClient) ReadUnsigned32(objectRef string, constraint FunctionalC
 	value := C.IedConnection_readUnsigned32Value(client.connection, &clientError, cObjectRef, C.FunctionalConstraint(constraint))
 
 	if clientError != C.IED_ERROR_OK {
-		return uint32(0), fmt.Errorf("failed to read object %s, clientError: %v", objectRef, Err(clientError))
+		return uint32(0), newClientError("read object", objectRef, clientError)
 	}
 
 	return uint32(value), nil
 }
 
-func (client *IedClient) resolveValue(value *C.MmsValue, valueType MMSType) interface{} {
-	goValue := interface{}(nil)
-
-	// Refer to https://support.mz-automation.de/doc/libiec61850/c/latest/group__MMS__VALUE.html
-
-	switch valueType {
-	case MMS_BOOLEAN:
-		goValue = bool(C.MmsValue_getBoolean(value))
-	case MMS_FLOAT:
-		goValue = float64(C.MmsValue_toDouble(value))
-	case MMS_INTEGER:
-		goValue = int64(C.MmsValue_toInt64(value))
-	case MMS_UNSIGNED:
-		goValue = int64(C.MmsValue_toInt64(value))
-	case MMS_STRING:
-		goValue = C.GoString(C.MmsValue_toString(value))
-	case MMS_VISIBLE_STRING:
-		goValue = C.GoString(C.MmsValue_toString(value))
-	case MMS_STRUCTURE:
-		goValue = client.digIntoStructure(value, valueType)
-	case MMS_ARRAY:
-		goValue = client.digIntoStructure(value, valueType)
-	case MMS_BIT_STRING:
-		if client.withoutTimestamps {
-			return 0
-		}
-		goValue = uint32(C.MmsValue_getBitStringAsInteger(value))
-	case MMS_UTC_TIME:
-		if client.withoutTimestamps {
-			return 0
-		}
-		goValue = uint32(C.MmsValue_toUnixTimestamp(value))
-	}
-
-	return goValue
-}
-
-// resolveValueInline 内联版本的值解析，减少interface{}装箱和函数调用开销
-// 使用unsafe优化字符串转换，避免不必要的内存拷贝
-func (client *IedClient) resolveValueInline(target *GoMmsValue, value *C.MmsValue, valueType MMSType) {
-	// Refer to https://support.mz-automation.de/doc/libiec61850/c/latest/group__MMS__VALUE.html
-
-	switch valueType {
-	case MMS_BOOLEAN:
-		target.Value = bool(C.MmsValue_getBoolean(value))
-	case MMS_FLOAT:
-		target.Value = float64(C.MmsValue_toDouble(value))
-	case MMS_INTEGER:
-		target.Value = int64(C.MmsValue_toInt64(value))
-	case MMS_UNSIGNED:
-		target.Value = int64(C.MmsValue_toInt64(value))
-	case MMS_STRING:
-		// 使用unsafe优化：避免C.GoString的内存拷贝
-		cStr := C.MmsValue_toString(value)
-		if cStr != nil {
-			target.Value = cStringToGoString(cStr)
-		} else {
-			target.Value = ""
-		}
-	case MMS_VISIBLE_STRING:
-		cStr := C.MmsValue_toString(value)
-		if cStr != nil {
-			target.Value = cStringToGoString(cStr)
-		} else {
-			target.Value = ""
-		}
-	case MMS_STRUCTURE:
-		target.Value = client.digIntoStructureOptimized(value, valueType)
-	case MMS_ARRAY:
-		target.Value = client.digIntoStructureOptimized(value, valueType)
-	case MMS_BIT_STRING:
-		if client.withoutTimestamps {
-			target.Value = 0
-			return
-		}
-		target.Value = uint32(C.MmsValue_getBitStringAsInteger(value))
-	case MMS_UTC_TIME:
-		if client.withoutTimestamps {
-			target.Value = 0
-			return
-		}
-		target.Value = uint32(C.MmsValue_toUnixTimestamp(value))
-	default:
-		target.Value = nil
+func (client *IedClient) ReadQuality(objectRef string, constraint FunctionalConstraint) (Quality, error) {
+	if err := client.acquire(); err != nil {
+		return Quality(0), err
+	}
+	defer client.release()
+
+	cObjectRef := C.CString(objectRef)
+	defer C.free(unsafe.Pointer(cObjectRef))
+
+	var clientError C.IedClientError
+	value := C.IedConnection_readQualityValue(client.connection, &clientError, cObjectRef, C.FunctionalConstraint(constraint))
+
+	if clientError != C.IED_ERROR_OK {
+		return Quality(0), newClientError("read object", objectRef, clientError)
+	}
+
+	return Quality(value), nil
+}
+
+func (client *IedClient) ReadTimestamp(objectRef string, constraint FunctionalConstraint) (Timestamp, error) {
+	if err := client.acquire(); err != nil {
+		return Timestamp{}, err
+	}
+	defer client.release()
+
+	cObjectRef := C.CString(objectRef)
+	defer C.free(unsafe.Pointer(cObjectRef))
+
+	var clientError C.IedClientError
+	var value C.Timestamp
+	C.IedConnection_readTimestampValue(client.connection, &clientError, cObjectRef, C.FunctionalConstraint(constraint), &value)
+
+	if clientError != C.IED_ERROR_OK {
+		return Timestamp{}, newClientError("read object", objectRef, clientError)
+	}
+
+	// the C union Timestamp is the raw UtcTime buffer
+	return UtcTime(value).Timestamp(), nil
+}
+
+// ReadObject read a functional constrained data attribute or a whole data object (returned as Structure)
+func (client *IedClient) ReadObject(objectRef string, constraint FunctionalConstraint) (MmsValue, error) {
+	if err := client.acquire(); err != nil {
+		return nil, err
+	}
+	defer client.release()
+
+	cObjectRef := C.CString(objectRef)
+	defer C.free(unsafe.Pointer(cObjectRef))
+
+	var clientError C.IedClientError
+	value := C.IedConnection_readObject(client.connection, &clientError, cObjectRef, C.FunctionalConstraint(constraint))
+
+	if clientError != C.IED_ERROR_OK {
+		return nil, newClientError("read object", objectRef, clientError)
+	}
+
+	defer C.MmsValue_delete(value)
+
+	return client.decodeNamed(objectRef, client.toMmsValue(value)), nil
+}
+
+func (client *IedClient) WriteBoolean(objectRef string, constraint FunctionalConstraint, value bool) error {
+	if err := client.acquire(); err != nil {
+		return err
+	}
+	defer client.release()
+
+	cObjectRef := C.CString(objectRef)
+	defer C.free(unsafe.Pointer(cObjectRef))
+
+	var clientError C.IedClientError
+	C.IedConnection_writeBooleanValue(client.connection, &clientError, cObjectRef, C.FunctionalConstraint(constraint), C.bool(value))
+
+	if clientError != C.IED_ERROR_OK {
+		return newClientError("write object", objectRef, clientError)
+	}
+
+	return nil
+}
+
+func (client *IedClient) WriteFloat(objectRef string, constraint FunctionalConstraint, value float32) error {
+	if err := client.acquire(); err != nil {
+		return err
+	}
+	defer client.release()
+
+	cObjectRef := C.CString(objectRef)
+	defer C.free(unsafe.Pointer(cObjectRef))
+
+	var clientError C.IedClientError
+	C.IedConnection_writeFloatValue(client.connection, &clientError, cObjectRef, C.FunctionalConstraint(constraint), C.float(value))
+
+	if clientError != C.IED_ERROR_OK {
+		return newClientError("write object", objectRef, clientError)
+	}
+
+	return nil
+}
+
+func (client *IedClient) WriteInt32(objectRef string, constraint FunctionalConstraint, value int32) error {
+	if err := client.acquire(); err != nil {
+		return err
+	}
+	defer client.release()
+
+	cObjectRef := C.CString(objectRef)
+	defer C.free(unsafe.Pointer(cObjectRef))
+
+	var clientError C.IedClientError
+	C.IedConnection_writeInt32Value(client.connection, &clientError, cObjectRef, C.FunctionalConstraint(constraint), C.int32_t(value))
+
+	if clientError != C.IED_ERROR_OK {
+		return newClientError("write object", objectRef, clientError)
+	}
+
+	return nil
+}
+
+// WriteInt64 there is no IedConnection_writeInt64Value, so the value is written as an MMS integer object
+func (client *IedClient) WriteInt64(objectRef string, constraint FunctionalConstraint, value int64) error {
+	mmsValue := C.MmsValue_newIntegerFromInt64(C.int64_t(value))
+	defer C.MmsValue_delete(mmsValue)
+
+	return client.writeMmsValue(objectRef, constraint, mmsValue)
+}
+
+func (client *IedClient) WriteUnsigned32(objectRef string, constraint FunctionalConstraint, value uint32) error {
+	if err := client.acquire(); err != nil {
+		return err
+	}
+	defer client.release()
+
+	cObjectRef := C.CString(objectRef)
+	defer C.free(unsafe.Pointer(cObjectRef))
+
+	var clientError C.IedClientError
+	C.IedConnection_writeUnsigned32Value(client.connection, &clientError, cObjectRef, C.FunctionalConstraint(constraint), C.uint32_t(value))
+
+	if clientError != C.IED_ERROR_OK {
+		return newClientError("write object", objectRef, clientError)
+	}
+
+	return nil
+}
+
+func (client *IedClient) WriteVisibleString(objectRef string, constraint FunctionalConstraint, value string) error {
+	if err := client.acquire(); err != nil {
+		return err
+	}
+	defer client.release()
+
+	cObjectRef := C.CString(objectRef)
+	defer C.free(unsafe.Pointer(cObjectRef))
+
+	cValue := C.CString(value)
+	defer C.free(unsafe.Pointer(cValue))
+
+	var clientError C.IedClientError
+	C.IedConnection_writeVisibleStringValue(client.connection, &clientError, cObjectRef, C.FunctionalConstraint(constraint), cValue)
+
+	if clientError != C.IED_ERROR_OK {
+		return newClientError("write object", objectRef, clientError)
+	}
+
+	return nil
+}
+
+// WriteBitString bitSize must match the size of the bit string on the server (e.g. 13 for Quality, 6 for TrgOps)
+func (client *IedClient) WriteBitString(objectRef string, constraint FunctionalConstraint, value uint32, bitSize int) error {
+	if bitSize <= 0 || bitSize > 32 {
+		return fmt.Errorf("failed to write object %s, invalid bit string size: %d", objectRef, bitSize)
+	}
+
+	mmsValue := C.MmsValue_newBitString(C.int(bitSize))
+	defer C.MmsValue_delete(mmsValue)
+
+	C.MmsValue_setBitStringFromInteger(mmsValue, C.uint32_t(value))
+
+	return client.writeMmsValue(objectRef, constraint, mmsValue)
+}
+
+func (client *IedClient) WriteUtcTime(objectRef string, constraint FunctionalConstraint, value time.Time) error {
+	mmsValue := C.MmsValue_newUtcTimeByMsTime(C.uint64_t(value.UnixMilli()))
+	defer C.MmsValue_delete(mmsValue)
+
+	return client.writeMmsValue(objectRef, constraint, mmsValue)
+}
+
+// WriteObject write a functional constrained data attribute or a whole data object (value is a Structure)
+func (client *IedClient) WriteObject(objectRef string, constraint FunctionalConstraint, value MmsValue) error {
+	mmsValue, err := newMmsValue(value)
+	if err != nil {
+		return fmt.Errorf("failed to write object %s, %w", objectRef, err)
+	}
+	defer C.MmsValue_delete(mmsValue)
+
+	return client.writeMmsValue(objectRef, constraint, mmsValue)
+}
+
+func (client *IedClient) writeMmsValue(objectRef string, constraint FunctionalConstraint, value *C.MmsValue) error {
+	if err := client.acquire(); err != nil {
+		return err
+	}
+	defer client.release()
+
+	cObjectRef := C.CString(objectRef)
+	defer C.free(unsafe.Pointer(cObjectRef))
+
+	var clientError C.IedClientError
+	C.IedConnection_writeObject(client.connection, &clientError, cObjectRef, C.FunctionalConstraint(constraint), value)
+
+	if clientError != C.IED_ERROR_OK {
+		return newClientError("write object", objectRef, clientError)
 	}
+
+	return nil
 }
 
 // cStringToGoString 使用unsafe将C字符串高效转换为Go字符串
@@ -310,57 +550,12 @@ func cStringToGoStringUnsafe(cStr *C.char) string {
 	return s
 }
 
-func (client *IedClient) digIntoStructure(mms *C.MmsValue, vType MMSType) []GoMmsValue {
-	if vType != MMS_STRUCTURE {
-		return nil
-	}
-	goValues := make([]GoMmsValue, 0)
-	index := 0
-	for {
-		value := C.MmsValue_getElement(mms, C.int(index))
-		if value == nil {
-			return goValues
-		}
-		valueType := C.MmsValue_getType(value)
-		var goValue GoMmsValue
-		goValue.Value = client.resolveValue(value, MMSType(valueType))
-		goValue.Type = (MMSType)(valueType)
-		goValues = append(goValues, goValue)
-		index++
-	}
-}
-
-// digIntoStructureOptimized 优化版本，减少内存分配和函数调用开销
-func (client *IedClient) digIntoStructureOptimized(mms *C.MmsValue, vType MMSType) []GoMmsValue {
-	if vType != MMS_STRUCTURE {
-		return nil
-	}
-
-	// 预分配一个合理大小的切片（大多数结构体不会超过32个元素）
-	// 这样可以减少大部分情况下的内存重分配
-	goValues := make([]GoMmsValue, 0, 32)
-
-	index := 0
-	for {
-		cIndex := C.int(index)
-		value := C.MmsValue_getElement(mms, cIndex)
-		if value == nil {
-			return goValues
-		}
-
-		valueType := MMSType(C.MmsValue_getType(value))
-
-		// 直接在切片中追加，避免创建临时变量
-		goValues = append(goValues, GoMmsValue{Type: valueType})
-
-		// 使用内联版本减少函数调用开销
-		client.resolveValueInline(&goValues[index], value, valueType)
-
-		index++
+func (client *IedClient) ReadDataSetValues(dataSetReference string, identifier string) ([]MmsValue, error) {
+	if err := client.acquire(); err != nil {
+		return nil, err
 	}
-}
+	defer client.release()
 
-func (client *IedClient) ReadDataSetValues(dataSetReference string, identifier string) ([]GoMmsValue, error) {
 	var clientError C.IedClientError
 
 	cDataSetReference := C.CString(dataSetReference)
@@ -369,7 +564,7 @@ func (client *IedClient) ReadDataSetValues(dataSetReference string, identifier s
 	clientDataSet := C.IedConnection_readDataSetValues(client.connection, &clientError, cDataSetReference, nil)
 
 	if clientError != C.IED_ERROR_OK {
-		return nil, fmt.Errorf("failed to read dataset values, error code: %s", Err(clientError))
+		return nil, newClientError("read dataset values", dataSetReference, clientError)
 	}
 
 	defer C.ClientDataSet_destroy(clientDataSet)
@@ -379,27 +574,9 @@ func (client *IedClient) ReadDataSetValues(dataSetReference string, identifier s
 	size := int(C.ClientDataSet_getDataSetSize(clientDataSet))
 
 	// 预分配切片，减少内存分配
-	goValues := make([]GoMmsValue, size)
-
-	// 使用unsafe优化：获取切片底层数组的指针，减少边界检查
-	if size > 0 {
-		// 直接访问切片底层数组
-		valuesPtr := unsafe.Pointer(&goValues[0])
-
-		// 遍历数据集中的值 - 优化：减少C类型转换次数和边界检查
-		for i := 0; i < size; i++ {
-			// 使用指针算术直接访问数组元素，避免切片索引的边界检查
-			target := (*GoMmsValue)(unsafe.Pointer(uintptr(valuesPtr) + uintptr(i)*unsafe.Sizeof(GoMmsValue{})))
-
-			// 减少类型转换：提前转换索引
-			cIndex := C.int(i)
-			value := C.MmsValue_getElement(values, cIndex)
-			valueType := MMSType(C.MmsValue_getType(value))
-
-			target.Type = valueType
-			// 直接内联解析，避免函数调用开销
-			client.resolveValueInline(target, value, valueType)
-		}
+	goValues := make([]MmsValue, size)
+	for i := 0; i < size; i++ {
+		goValues[i] = client.toMmsValue(C.MmsValue_getElement(values, C.int(i)))
 	}
 
 	return goValues, nil
@@ -423,12 +600,15 @@ func findDAName(das []scl_xml.DA, index int) string {
 	return ""
 }
 
-func (client *IedClient) ExplainDataSetValues(values []GoMmsValue, dSetScl *scl_xml.DataSetDetail) (map[string]interface{}, error) {
+// ExplainDataSetValues map the values of a data set to the references of its members. A structured member without
+// DAName is split into its data attributes named by the DOType of the SCL, a data attribute with a single component
+// is mapped to its name, e.g. "IEDLD0/MMXU1.TotW.mag". See ExplainDataSetComponents for the basic components.
+func (client *IedClient) ExplainDataSetValues(values []MmsValue, dSetScl *scl_xml.DataSetDetail) (map[string]MmsValue, error) {
 	if len(dSetScl.FCDA) != len(values) {
 		return nil, errors.New("error dataset scl")
 	}
 
-	ret := make(map[string]interface{})
+	ret := make(map[string]MmsValue)
 	for idx, entity := range dSetScl.FCDA {
 		var builder strings.Builder
 
@@ -445,9 +625,9 @@ func (client *IedClient) ExplainDataSetValues(values []GoMmsValue, dSetScl *scl_
 		if entity.DAName != "" {
 			builder.WriteString(".")
 			builder.WriteString(entity.DAName)
-			ret[builder.String()] = val.Value
+			ret[builder.String()] = val
 		} else {
-			if valueList, ok := val.Value.([]GoMmsValue); ok {
+			if valueList, ok := val.(Structure); ok {
 				doTyp := dSetScl.GetDOType(entity.Prefix, entity.LNClass, entity.DOName)
 				for i, v := range valueList {
 					if len(doTyp.DA) > i+1 {
@@ -458,16 +638,16 @@ func (client *IedClient) ExplainDataSetValues(values []GoMmsValue, dSetScl *scl_
 						builder.WriteString(strconv.Itoa(i))
 					}
 
-					switch rv := v.Value.(type) {
-					case []GoMmsValue:
+					switch rv := v.(type) {
+					case Structure:
 						if len(rv) != 1 {
 							fmt.Printf("[Wraning] ExplainDataSetValues has error length, ref: %s, value: %+v\n", builder.String(), v)
 							continue
 						}
-						ret[builder.String()] = rv[0].Value
+						ret[builder.String()] = rv[0]
 						continue
 					}
-					ret[builder.String()] = v.Value
+					ret[builder.String()] = v
 				}
 			}
 		}
@@ -475,12 +655,160 @@ func (client *IedClient) ExplainDataSetValues(values []GoMmsValue, dSetScl *scl_
 		builder.Reset()
 	}
 
+	for path, value := range ret {
+		ret[path] = client.decodeNamed(path, value)
+	}
+
 	return ret, nil
 }
 
+// ExplainDataSetComponents map the values of a data set to the references of the basic components of its members,
+// e.g. "IEDLD0/MMXU1.TotW.mag.f". The components of a structured member are named by its type specification read
+// from the IED, without type specification by the DOType, DAType and BDA definitions of the SCL. The type
+// specification costs one request per structured member on the first call, the answer of the IED, a rejection too,
+// is kept until the client connects again.
+func (client *IedClient) ExplainDataSetComponents(values []MmsValue, dSetScl *scl_xml.DataSetDetail) (map[string]MmsValue, error) {
+	if len(dSetScl.FCDA) != len(values) {
+		return nil, errors.New("error dataset scl")
+	}
+
+	ret := make(map[string]MmsValue)
+	for idx, entity := range dSetScl.FCDA {
+		ref := fcdaReference(dSetScl.IEDName, entity)
+		val := values[idx]
+
+		switch val.(type) {
+		case Structure, Array:
+			if spec, err := client.fcdaSpec(ref, entity.FC); err == nil {
+				flattenLeaves(spec, val, ref, ret)
+				continue
+			}
+		default:
+			ret[ref] = val
+			continue
+		}
+
+		if node, err := dSetScl.ResolveFCDA(entity); err == nil {
+			explainByType(node, entity.FC, val, ref, func(path string, value MmsValue) {
+				ret[path] = value
+			})
+			continue
+		}
+
+		ret[ref] = val
+	}
+
+	for path, value := range ret {
+		ret[path] = client.decodeNamed(path, value)
+	}
+
+	return ret, nil
+}
+
+// fcdaReference the reference of a data set member in the MMS domain of the IED, e.g. "IEDLD0/MMXU1.TotW"
+func fcdaReference(iedName string, fcda scl_xml.FCDAEntry) string {
+	var builder strings.Builder
+
+	builder.WriteString(iedName)
+	builder.WriteString(fcda.LDInst)
+	builder.WriteString("/")
+	builder.WriteString(fcda.Prefix)
+	builder.WriteString(fcda.LNClass)
+	builder.WriteString(fcda.LNInst)
+	builder.WriteString(".")
+	builder.WriteString(fcda.DOName)
+	if fcda.DAName != "" {
+		builder.WriteString(".")
+		builder.WriteString(fcda.DAName)
+	}
+
+	return builder.String()
+}
+
+// fcdaSpec the type specification of a data set member, fc is the functional constraint of the SCL, e.g. "MX"
+func (client *IedClient) fcdaSpec(ref string, fc string) (*MmsVariableSpecification, error) {
+	cFc := C.CString(fc)
+	defer C.free(unsafe.Pointer(cFc))
+
+	constraint := FunctionalConstraint(C.FunctionalConstraint_fromString(cFc))
+	if constraint == IEC61850_FC_NONE {
+		return nil, fmt.Errorf("invalid functional constraint %s of %s", fc, ref)
+	}
+
+	key := specKey(ref, constraint)
+
+	client.specMutex.Lock()
+	rejected, ok := client.specRejected[key]
+	client.specMutex.Unlock()
+	if ok {
+		return nil, rejected
+	}
+
+	spec, err := client.getVariableSpec(ref, constraint)
+	if err == nil {
+		return spec, nil
+	}
+
+	// the IED does not know the member, do not ask again until the next connect
+	var clientError *ClientError
+	if errors.As(err, &clientError) && clientError.Code != IED_ERROR_NOT_CONNECTED &&
+		clientError.Code != IED_ERROR_CONNECTION_LOST && clientError.Code != IED_ERROR_TIMEOUT {
+		client.specMutex.Lock()
+		if client.specRejected == nil {
+			client.specRejected = make(map[string]error)
+		}
+		client.specRejected[key] = err
+		client.specMutex.Unlock()
+	}
+
+	return nil, err
+}
+
+// Close close the connection and release it. Calls started later return ErrClientClosed, Close waits for the calls
+// in progress, which fail as the connection is closed. Calling Close more than once is safe.
 func (client *IedClient) Close() {
-	C.IedConnection_close(client.connection)
-	C.IedConnection_destroy(client.connection)
+	client.closeOnce.Do(func() {
+		client.closeMutex.Lock()
+		client.closed = true
+		client.closeMutex.Unlock()
+
+		client.notifier.requestClose(true)
+		C.IedConnection_close(client.connection)
+
+		failAsyncCalls(client)
+		client.inFlight.Wait()
+
+		client.closeControlObjects()
+		C.IedConnection_destroy(client.connection)
+		if client.tlsConfiguration != nil {
+			destroyTLSConfiguration(client.tlsConfiguration)
+		}
+		if client.authParameter != nil {
+			C.AcseAuthenticationParameter_destroy(client.authParameter)
+		}
+		client.notifier.stop()
+		client.stopReports()
+		client.clearSpecCache()
+
+		runtime.SetFinalizer(client, nil)
+	})
+}
+
+// acquire mark a call using the connection, every successful acquire has to be followed by release
+func (client *IedClient) acquire() error {
+	client.closeMutex.Lock()
+	defer client.closeMutex.Unlock()
+
+	if client.closed {
+		return ErrClientClosed
+	}
+
+	client.inFlight.Add(1)
+	return nil
+}
+
+func (client *IedClient) release() {
+	client.inFlight.Done()
 }
 
 func printSpaces(count int) {
@@ -490,6 +818,11 @@ func printSpaces(count int) {
 }
 
 func (client *IedClient) BrowseDataAttributes(doRef string, spaces int) {
+	if err := client.acquire(); err != nil {
+		return
+	}
+	defer client.release()
+
 	var clientError C.IedClientError
 
 	dataAttributes := C.IedConnection_getDataDirectory(client.connection, &clientError, C.CString(doRef))
@@ -509,6 +842,11 @@ func (client *IedClient) BrowseDataAttributes(doRef string, spaces int) {
 }
 
 func (client *IedClient) BrowseModel() {
+	if err := client.acquire(); err != nil {
+		return
+	}
+	defer client.release()
+
 	var clientError C.IedClientError
 
 	// Get Logical Device List
@@ -558,6 +896,11 @@ func (client *IedClient) BrowseModel() {
 }
 
 func (client *IedClient) BrowseDataAttributesSCL(ref string) []scl_xml.DAI {
+	if err := client.acquire(); err != nil {
+		return nil
+	}
+	defer client.release()
+
 	var dais []scl_xml.DAI
 	var clientError C.IedClientError
 
@@ -588,6 +931,11 @@ func (client *IedClient) BrowseDataAttributesSCL(ref string) []scl_xml.DAI {
 }
 
 func (client *IedClient) BrowseSDISCL(ref string) []scl_xml.SDI {
+	if err := client.acquire(); err != nil {
+		return nil
+	}
+	defer client.release()
+
 	var sdis []scl_xml.SDI
 	var clientError C.IedClientError
 
@@ -618,6 +966,11 @@ func (client *IedClient) BrowseSDISCL(ref string) []scl_xml.SDI {
 }
 
 func (client *IedClient) BrowseModelToSCL() (*scl_xml.SCL, error) {
+	if err := client.acquire(); err != nil {
+		return nil, err
+	}
+	defer client.release()
+
 	scl := &scl_xml.SCL{}
 	var clientError C.IedClientError
 
@@ -625,7 +978,7 @@ func (client *IedClient) BrowseModelToSCL() (*scl_xml.SCL, error) {
 	defer C.LinkedList_destroy(deviceList)
 
 	if clientError != 0 {
-		return nil, fmt.Errorf("failed to retrieve logical device list. Error: %s", Err(clientError))
+		return nil, newClientError("retrieve logical device list", "", clientError)
 	}
 
 	for device := C.LinkedList_getNext(deviceList); device != nil; device = C.LinkedList_getNext(device) {
@@ -0,0 +1,166 @@
+package iec61850
+
+// #include <iec61850_client.h>
+import "C"
+import (
+	"context"
+	"fmt"
+	"time"
+	"unsafe"
+)
+
+// connectPollInterval how often ConnectContext checks the state of a connection attempt
+const connectPollInterval = 10 * time.Millisecond
+
+// ConnectContext same as Connect, but the connection attempt is closed when ctx is done before it succeeds
+func (client *IedClient) ConnectContext(ctx context.Context, hostname string, tcpPort int) error {
+	if err := client.acquire(); err != nil {
+		return err
+	}
+	defer client.release()
+
+	if client.optionErr != nil {
+		return client.optionErr
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	cHostname := C.CString(hostname)
+	defer C.free(unsafe.Pointer(cHostname))
+
+	client.notifier.requestClose(false)
+	client.setPeerAddress(hostname, tcpPort)
+	client.clearSpecCache()
+
+	start := time.Now()
+
+	var clientError C.IedClientError
+	C.IedConnection_connectAsync(client.connection, &clientError, cHostname, C.int(tcpPort))
+	if clientError == C.IED_ERROR_ALREADY_CONNECTED {
+		return nil
+	} else if clientError != C.IED_ERROR_OK {
+		return newClientError("connect to", fmt.Sprintf("%s:%d", hostname, tcpPort), clientError)
+	}
+
+	ticker := time.NewTicker(connectPollInterval)
+	defer ticker.Stop()
+
+	for {
+		switch client.State() {
+		case IED_STATE_CONNECTED:
+			return nil
+		case IED_STATE_CLOSED:
+			// libiec61850 does not report why an asynchronous connect failed
+			code := IED_ERROR_CONNECTION_REJECTED
+			if time.Since(start) >= client.connectTimeout {
+				code = IED_ERROR_TIMEOUT
+			}
+			return &ClientError{Op: "connect to", Ref: fmt.Sprintf("%s:%d", hostname, tcpPort), Code: code}
+		}
+
+		select {
+		case <-ctx.Done():
+			client.notifier.requestClose(true)
+			if client.State() == IED_STATE_CONNECTING {
+				C.MmsConnection_close(C.IedConnection_getMmsConnection(client.connection))
+			} else {
+				C.IedConnection_close(client.connection)
+			}
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// ReadContext same as ReadObject, returns ctx.Err() when ctx is done before the response is received. The request
+// is bound by the deadline of ctx, see waitAsyncCall.
+func (client *IedClient) ReadContext(ctx context.Context, objectRef string, constraint FunctionalConstraint) (MmsValue, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	call, err := client.ReadObjectAsync(objectRef, constraint, contextTimeout(ctx), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := waitAsyncCall(ctx, call)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Value, nil
+}
+
+// WriteContext same as WriteObject, returns ctx.Err() when ctx is done before the response is received. The request
+// is bound by the deadline of ctx, see waitAsyncCall.
+func (client *IedClient) WriteContext(ctx context.Context, objectRef string, constraint FunctionalConstraint, value MmsValue) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	call, err := client.WriteObjectAsync(objectRef, constraint, value, contextTimeout(ctx), nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = waitAsyncCall(ctx, call)
+	return err
+}
+
+// ReadDataSetValuesContext same as ReadDataSetValues, returns ctx.Err() when ctx is done before the response is
+// received. The request is bound by the deadline of ctx, see waitAsyncCall.
+func (client *IedClient) ReadDataSetValuesContext(ctx context.Context, dataSetReference string) ([]MmsValue, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	call, err := client.ReadDataSetValuesAsync(dataSetReference, contextTimeout(ctx), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := waitAsyncCall(ctx, call)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Values, nil
+}
+
+// contextTimeout the time left until the deadline of ctx, 0 without deadline
+func contextTimeout(ctx context.Context) time.Duration {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return 0
+	}
+	if timeout := time.Until(deadline); timeout > 0 {
+		return timeout
+	}
+	return time.Nanosecond
+}
+
+// waitAsyncCall wait for the result of call, a call outstanding when ctx is done is cancelled. libiec61850 can not
+// abort a single MMS request, it is sent with the deadline of ctx as timeout, so its outstanding call expires at
+// the deadline. A request cancelled without deadline keeps its outstanding call until the RequestTimeout of the
+// client expires, the late response is dropped.
+func waitAsyncCall(ctx context.Context, call *AsyncCall) (AsyncResult, error) {
+	select {
+	case result := <-call.Done():
+		// the timeout of the request expires with the deadline of ctx
+		if result.Err != nil {
+			if err := ctx.Err(); err != nil {
+				return result, err
+			}
+			if deadline, ok := ctx.Deadline(); ok && !time.Now().Before(deadline) {
+				return result, context.DeadlineExceeded
+			}
+		}
+		return result, result.Err
+	case <-ctx.Done():
+		call.cancel()
+		return AsyncResult{}, ctx.Err()
+	}
+}
@@ -1,14 +1,31 @@
 package iec61850
 
-// #include <iec61850_client.h>
+/*
+#include <stdint.h>
+#include <iec61850_client.h>
+
+extern void goControlActionHandler(uint32_t invokeId, void* parameter, IedClientError err, ControlActionType type, bool success);
+
+static uint32_t operateAsync(ControlObjectClient self, IedClientError* err, MmsValue* ctlVal, uint64_t operTime, uintptr_t id)
+{
+	return ControlObjectClient_operateAsync(self, err, ctlVal, operTime, goControlActionHandler, (void*) id);
+}
+*/
 import "C"
 import (
+	"context"
 	"fmt"
 	"unsafe"
 )
 
-// DirectWithNormalSecurity Get the value of the specified data set
+// DirectWithNormalSecurity operate a direct-with-normal-security control object with a boolean ctlVal, use
+// NewControlObject for the other control models
 func (client *IedClient) DirectWithNormalSecurity(controlReference string, val bool) error {
+	if err := client.acquire(); err != nil {
+		return err
+	}
+	defer client.release()
+
 	//var clientError C.IedClientError
 
 	cDataSetReference := C.CString(controlReference)
@@ -33,3 +50,57 @@ func (client *IedClient) DirectWithNormalSecurity(controlReference string, val b
 	}
 
 }
+
+// DirectWithNormalSecurityContext same as DirectWithNormalSecurity, returns ctx.Err() when ctx is done before the
+// operate response is received. Creating the control object still reads its specification with a blocking request.
+func (client *IedClient) DirectWithNormalSecurityContext(ctx context.Context, controlReference string, val bool) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if err := client.acquire(); err != nil {
+		return err
+	}
+
+	cControlReference := C.CString(controlReference)
+	defer C.free(unsafe.Pointer(cControlReference))
+
+	control := C.ControlObjectClient_create(cControlReference, client.connection)
+	if control == nil {
+		client.release()
+		return fmt.Errorf("error creating control object client")
+	}
+
+	ctlVal := C.MmsValue_newBoolean(C._Bool(val))
+	defer C.MmsValue_delete(ctlVal)
+
+	C.ControlObjectClient_setOrigin(control, nil, 3)
+
+	call := client.newAsyncCall("operate", controlReference, nil)
+
+	var clientError C.IedClientError
+	invokeId := C.operateAsync(control, &clientError, ctlVal, 0, C.uintptr_t(call.id))
+
+	if clientError != C.IED_ERROR_OK {
+		C.ControlObjectClient_destroy(control)
+		client.release()
+		return call.abort(clientError)
+	}
+
+	call.start(uint32(invokeId), 0)
+
+	select {
+	case result := <-call.Done():
+		C.ControlObjectClient_destroy(control)
+		client.release()
+		return result.Err
+	case <-ctx.Done():
+		// the outstanding operate references the control object until its response or the request timeout
+		go func() {
+			<-call.Done()
+			C.ControlObjectClient_destroy(control)
+			client.release()
+		}()
+		return ctx.Err()
+	}
+}
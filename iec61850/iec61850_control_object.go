@@ -0,0 +1,531 @@
+package iec61850
+
+/*
+#include <stdint.h>
+#include <iec61850_client.h>
+
+extern void goCommandTerminationHandler(void* parameter, ControlObjectClient controlClient);
+
+static void setCommandTerminationHandler(ControlObjectClient self, uintptr_t id)
+{
+	ControlObjectClient_setCommandTerminationHandler(self, goCommandTerminationHandler, (void*) id);
+}
+
+// the ctlNum of an operate is set to match its CommandTermination, libiec61850 does not return the ctlNum it sends
+static void setCtlNum(ControlObjectClient self, uint8_t ctlNum)
+{
+#pragma GCC diagnostic push
+#pragma GCC diagnostic ignored "-Wdeprecated-declarations"
+	ControlObjectClient_setCtlNum(self, ctlNum);
+#pragma GCC diagnostic pop
+}
+*/
+import "C"
+import (
+	"fmt"
+	"sync"
+	"time"
+	"unsafe"
+)
+
+// ControlModel the ctlModel of a controllable data object
+type ControlModel int
+
+const (
+	CONTROL_MODEL_STATUS_ONLY     ControlModel = C.CONTROL_MODEL_STATUS_ONLY
+	CONTROL_MODEL_DIRECT_NORMAL   ControlModel = C.CONTROL_MODEL_DIRECT_NORMAL
+	CONTROL_MODEL_SBO_NORMAL      ControlModel = C.CONTROL_MODEL_SBO_NORMAL
+	CONTROL_MODEL_DIRECT_ENHANCED ControlModel = C.CONTROL_MODEL_DIRECT_ENHANCED
+	CONTROL_MODEL_SBO_ENHANCED    ControlModel = C.CONTROL_MODEL_SBO_ENHANCED
+)
+
+func (m ControlModel) String() string {
+	switch m {
+	case CONTROL_MODEL_STATUS_ONLY:
+		return "status-only"
+	case CONTROL_MODEL_DIRECT_NORMAL:
+		return "direct-with-normal-security"
+	case CONTROL_MODEL_SBO_NORMAL:
+		return "sbo-with-normal-security"
+	case CONTROL_MODEL_DIRECT_ENHANCED:
+		return "direct-with-enhanced-security"
+	case CONTROL_MODEL_SBO_ENHANCED:
+		return "sbo-with-enhanced-security"
+	}
+
+	return fmt.Sprintf("ctlModel(%d)", int(m))
+}
+
+// selectBeforeOperate whether the control object has to be selected before it can be operated
+func (m ControlModel) selectBeforeOperate() bool {
+	return m == CONTROL_MODEL_SBO_NORMAL || m == CONTROL_MODEL_SBO_ENHANCED
+}
+
+// enhancedSecurity whether the IED reports the end of the operation by a CommandTermination
+func (m ControlModel) enhancedSecurity() bool {
+	return m == CONTROL_MODEL_DIRECT_ENHANCED || m == CONTROL_MODEL_SBO_ENHANCED
+}
+
+// ControlLastApplError the Error of a LastApplError
+type ControlLastApplError int
+
+const (
+	CONTROL_ERROR_NO_ERROR      ControlLastApplError = C.CONTROL_ERROR_NO_ERROR
+	CONTROL_ERROR_UNKNOWN       ControlLastApplError = C.CONTROL_ERROR_UNKNOWN
+	CONTROL_ERROR_TIMEOUT_TEST  ControlLastApplError = C.CONTROL_ERROR_TIMEOUT_TEST
+	CONTROL_ERROR_OPERATOR_TEST ControlLastApplError = C.CONTROL_ERROR_OPERATOR_TEST
+)
+
+func (e ControlLastApplError) String() string {
+	switch e {
+	case CONTROL_ERROR_NO_ERROR:
+		return "CONTROL_ERROR_NO_ERROR"
+	case CONTROL_ERROR_UNKNOWN:
+		return "CONTROL_ERROR_UNKNOWN"
+	case CONTROL_ERROR_TIMEOUT_TEST:
+		return "CONTROL_ERROR_TIMEOUT_TEST"
+	case CONTROL_ERROR_OPERATOR_TEST:
+		return "CONTROL_ERROR_OPERATOR_TEST"
+	}
+
+	return "CONTROL_ERROR_UNDEFINED"
+}
+
+// ControlAddCause why the IED rejected a control, test a ControlError with errors.Is, e.g.
+// errors.Is(err, ADD_CAUSE_BLOCKED_BY_INTERLOCKING)
+type ControlAddCause int
+
+const (
+	ADD_CAUSE_UNKNOWN                        ControlAddCause = C.ADD_CAUSE_UNKNOWN
+	ADD_CAUSE_NOT_SUPPORTED                  ControlAddCause = C.ADD_CAUSE_NOT_SUPPORTED
+	ADD_CAUSE_BLOCKED_BY_SWITCHING_HIERARCHY ControlAddCause = C.ADD_CAUSE_BLOCKED_BY_SWITCHING_HIERARCHY
+	ADD_CAUSE_SELECT_FAILED                  ControlAddCause = C.ADD_CAUSE_SELECT_FAILED
+	ADD_CAUSE_INVALID_POSITION               ControlAddCause = C.ADD_CAUSE_INVALID_POSITION
+	ADD_CAUSE_POSITION_REACHED               ControlAddCause = C.ADD_CAUSE_POSITION_REACHED
+	ADD_CAUSE_PARAMETER_CHANGE_IN_EXECUTION  ControlAddCause = C.ADD_CAUSE_PARAMETER_CHANGE_IN_EXECUTION
+	ADD_CAUSE_STEP_LIMIT                     ControlAddCause = C.ADD_CAUSE_STEP_LIMIT
+	ADD_CAUSE_BLOCKED_BY_MODE                ControlAddCause = C.ADD_CAUSE_BLOCKED_BY_MODE
+	ADD_CAUSE_BLOCKED_BY_PROCESS             ControlAddCause = C.ADD_CAUSE_BLOCKED_BY_PROCESS
+	ADD_CAUSE_BLOCKED_BY_INTERLOCKING        ControlAddCause = C.ADD_CAUSE_BLOCKED_BY_INTERLOCKING
+	ADD_CAUSE_BLOCKED_BY_SYNCHROCHECK        ControlAddCause = C.ADD_CAUSE_BLOCKED_BY_SYNCHROCHECK
+	ADD_CAUSE_COMMAND_ALREADY_IN_EXECUTION   ControlAddCause = C.ADD_CAUSE_COMMAND_ALREADY_IN_EXECUTION
+	ADD_CAUSE_BLOCKED_BY_HEALTH              ControlAddCause = C.ADD_CAUSE_BLOCKED_BY_HEALTH
+	ADD_CAUSE_1_OF_N_CONTROL                 ControlAddCause = C.ADD_CAUSE_1_OF_N_CONTROL
+	ADD_CAUSE_ABORTION_BY_CANCEL             ControlAddCause = C.ADD_CAUSE_ABORTION_BY_CANCEL
+	ADD_CAUSE_TIME_LIMIT_OVER                ControlAddCause = C.ADD_CAUSE_TIME_LIMIT_OVER
+	ADD_CAUSE_ABORTION_BY_TRIP               ControlAddCause = C.ADD_CAUSE_ABORTION_BY_TRIP
+	ADD_CAUSE_OBJECT_NOT_SELECTED            ControlAddCause = C.ADD_CAUSE_OBJECT_NOT_SELECTED
+	ADD_CAUSE_OBJECT_ALREADY_SELECTED        ControlAddCause = C.ADD_CAUSE_OBJECT_ALREADY_SELECTED
+	ADD_CAUSE_NO_ACCESS_AUTHORITY            ControlAddCause = C.ADD_CAUSE_NO_ACCESS_AUTHORITY
+	ADD_CAUSE_ENDED_WITH_OVERSHOOT           ControlAddCause = C.ADD_CAUSE_ENDED_WITH_OVERSHOOT
+	ADD_CAUSE_ABORTION_DUE_TO_DEVIATION      ControlAddCause = C.ADD_CAUSE_ABORTION_DUE_TO_DEVIATION
+	ADD_CAUSE_ABORTION_BY_COMMUNICATION_LOSS ControlAddCause = C.ADD_CAUSE_ABORTION_BY_COMMUNICATION_LOSS
+	ADD_CAUSE_ABORTION_BY_COMMAND            ControlAddCause = C.ADD_CAUSE_ABORTION_BY_COMMAND
+	ADD_CAUSE_NONE                           ControlAddCause = C.ADD_CAUSE_NONE
+	ADD_CAUSE_INCONSISTENT_PARAMETERS        ControlAddCause = C.ADD_CAUSE_INCONSISTENT_PARAMETERS
+	ADD_CAUSE_LOCKED_BY_OTHER_CLIENT         ControlAddCause = C.ADD_CAUSE_LOCKED_BY_OTHER_CLIENT
+)
+
+var addCauseNames = map[ControlAddCause]string{
+	ADD_CAUSE_UNKNOWN:                        "ADD_CAUSE_UNKNOWN",
+	ADD_CAUSE_NOT_SUPPORTED:                  "ADD_CAUSE_NOT_SUPPORTED",
+	ADD_CAUSE_BLOCKED_BY_SWITCHING_HIERARCHY: "ADD_CAUSE_BLOCKED_BY_SWITCHING_HIERARCHY",
+	ADD_CAUSE_SELECT_FAILED:                  "ADD_CAUSE_SELECT_FAILED",
+	ADD_CAUSE_INVALID_POSITION:               "ADD_CAUSE_INVALID_POSITION",
+	ADD_CAUSE_POSITION_REACHED:               "ADD_CAUSE_POSITION_REACHED",
+	ADD_CAUSE_PARAMETER_CHANGE_IN_EXECUTION:  "ADD_CAUSE_PARAMETER_CHANGE_IN_EXECUTION",
+	ADD_CAUSE_STEP_LIMIT:                     "ADD_CAUSE_STEP_LIMIT",
+	ADD_CAUSE_BLOCKED_BY_MODE:                "ADD_CAUSE_BLOCKED_BY_MODE",
+	ADD_CAUSE_BLOCKED_BY_PROCESS:             "ADD_CAUSE_BLOCKED_BY_PROCESS",
+	ADD_CAUSE_BLOCKED_BY_INTERLOCKING:        "ADD_CAUSE_BLOCKED_BY_INTERLOCKING",
+	ADD_CAUSE_BLOCKED_BY_SYNCHROCHECK:        "ADD_CAUSE_BLOCKED_BY_SYNCHROCHECK",
+	ADD_CAUSE_COMMAND_ALREADY_IN_EXECUTION:   "ADD_CAUSE_COMMAND_ALREADY_IN_EXECUTION",
+	ADD_CAUSE_BLOCKED_BY_HEALTH:              "ADD_CAUSE_BLOCKED_BY_HEALTH",
+	ADD_CAUSE_1_OF_N_CONTROL:                 "ADD_CAUSE_1_OF_N_CONTROL",
+	ADD_CAUSE_ABORTION_BY_CANCEL:             "ADD_CAUSE_ABORTION_BY_CANCEL",
+	ADD_CAUSE_TIME_LIMIT_OVER:                "ADD_CAUSE_TIME_LIMIT_OVER",
+	ADD_CAUSE_ABORTION_BY_TRIP:               "ADD_CAUSE_ABORTION_BY_TRIP",
+	ADD_CAUSE_OBJECT_NOT_SELECTED:            "ADD_CAUSE_OBJECT_NOT_SELECTED",
+	ADD_CAUSE_OBJECT_ALREADY_SELECTED:        "ADD_CAUSE_OBJECT_ALREADY_SELECTED",
+	ADD_CAUSE_NO_ACCESS_AUTHORITY:            "ADD_CAUSE_NO_ACCESS_AUTHORITY",
+	ADD_CAUSE_ENDED_WITH_OVERSHOOT:           "ADD_CAUSE_ENDED_WITH_OVERSHOOT",
+	ADD_CAUSE_ABORTION_DUE_TO_DEVIATION:      "ADD_CAUSE_ABORTION_DUE_TO_DEVIATION",
+	ADD_CAUSE_ABORTION_BY_COMMUNICATION_LOSS: "ADD_CAUSE_ABORTION_BY_COMMUNICATION_LOSS",
+	ADD_CAUSE_ABORTION_BY_COMMAND:            "ADD_CAUSE_ABORTION_BY_COMMAND",
+	ADD_CAUSE_NONE:                           "ADD_CAUSE_NONE",
+	ADD_CAUSE_INCONSISTENT_PARAMETERS:        "ADD_CAUSE_INCONSISTENT_PARAMETERS",
+	ADD_CAUSE_LOCKED_BY_OTHER_CLIENT:         "ADD_CAUSE_LOCKED_BY_OTHER_CLIENT",
+}
+
+func (c ControlAddCause) String() string {
+	if name, ok := addCauseNames[c]; ok {
+		return name
+	}
+	return "ADD_CAUSE_UNDEFINED"
+}
+
+func (c ControlAddCause) Error() string {
+	return c.String()
+}
+
+// LastApplError the LastApplError the IED sent for a rejected select, operate or cancel, or with a
+// CommandTermination-
+type LastApplError struct {
+	CtlNum   int
+	Error    ControlLastApplError
+	AddCause ControlAddCause
+}
+
+// ControlError a failed select, operate or cancel. It wraps the IedError of the request and matches its AddCause
+// with errors.Is, the AddCause is ADD_CAUSE_UNKNOWN if the IED did not send a LastApplError.
+type ControlError struct {
+	Op            string
+	Ref           string
+	Code          IedError
+	LastApplError LastApplError
+}
+
+func (e *ControlError) Error() string {
+	return fmt.Sprintf("failed to %s %s, clientError: %v, lastApplError: %v, addCause: %v",
+		e.Op, e.Ref, e.Code, e.LastApplError.Error, e.LastApplError.AddCause)
+}
+
+func (e *ControlError) Unwrap() error {
+	return e.Code
+}
+
+func (e *ControlError) Is(target error) bool {
+	cause, ok := target.(ControlAddCause)
+	return ok && cause == e.LastApplError.AddCause
+}
+
+// defaultTerminationTimeout the time Operate waits for the CommandTermination of an enhanced security control
+const defaultTerminationTimeout = 10 * time.Second
+
+// ControlObject a controllable data object of the IED, e.g. "LD0/CSWI1.Pos". The ctlModel is read from the IED when
+// the control object is created. The methods of a ControlObject are serialized.
+type ControlObject struct {
+	client *IedClient
+	ref    string
+	id     uintptr
+
+	mutex              sync.Mutex
+	control            C.ControlObjectClient
+	terminationTimeout time.Duration
+	terminations       chan LastApplError
+	ctlNum             uint8
+}
+
+var (
+	controlObjectsMutex sync.Mutex
+	controlObjects      = make(map[uintptr]*ControlObject)
+	controlObjectId     uintptr
+)
+
+func lookupControlObject(id uintptr) *ControlObject {
+	controlObjectsMutex.Lock()
+	defer controlObjectsMutex.Unlock()
+
+	return controlObjects[id]
+}
+
+// NewControlObject read the ctlModel and the type of ctlVal of the control object. The origin is set to the
+// remote-control category (orCat 3) without orIdent. The control object is released by Close or when the client is
+// closed.
+func (client *IedClient) NewControlObject(controlReference string) (*ControlObject, error) {
+	if err := client.acquire(); err != nil {
+		return nil, err
+	}
+	defer client.release()
+
+	cControlReference := C.CString(controlReference)
+	defer C.free(unsafe.Pointer(cControlReference))
+
+	control := C.ControlObjectClient_create(cControlReference, client.connection)
+	if control == nil {
+		return nil, fmt.Errorf("failed to create control object %s", controlReference)
+	}
+
+	C.ControlObjectClient_setOrigin(control, nil, 3)
+
+	object := &ControlObject{
+		client:             client,
+		ref:                controlReference,
+		control:            control,
+		terminationTimeout: defaultTerminationTimeout,
+		terminations:       make(chan LastApplError, 1),
+	}
+
+	controlObjectsMutex.Lock()
+	controlObjectId++
+	object.id = controlObjectId
+	controlObjects[object.id] = object
+	controlObjectsMutex.Unlock()
+
+	C.setCommandTerminationHandler(control, C.uintptr_t(object.id))
+
+	client.controlsMutex.Lock()
+	if client.controls == nil {
+		client.controls = make(map[*ControlObject]struct{})
+	}
+	client.controls[object] = struct{}{}
+	client.controlsMutex.Unlock()
+
+	return object, nil
+}
+
+// Ref the object reference of the control object
+func (c *ControlObject) Ref() string {
+	return c.ref
+}
+
+// ControlModel the ctlModel read from the IED
+func (c *ControlObject) ControlModel() ControlModel {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.control == nil {
+		return CONTROL_MODEL_STATUS_ONLY
+	}
+	return ControlModel(C.ControlObjectClient_getControlModel(c.control))
+}
+
+// SetOrigin set orIdent and orCat sent with the following select, operate and cancel, e.g. 2 station-control,
+// 3 remote-control
+func (c *ControlObject) SetOrigin(orIdent string, orCat int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.control == nil {
+		return
+	}
+
+	cOrIdent := C.CString(orIdent)
+	defer C.free(unsafe.Pointer(cOrIdent))
+
+	C.ControlObjectClient_setOrigin(c.control, cOrIdent, C.int(orCat))
+}
+
+// SetTerminationTimeout the time Operate waits for the CommandTermination of an enhanced security control, 10s if
+// not set
+func (c *ControlObject) SetTerminationTimeout(timeout time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.terminationTimeout = timeout
+}
+
+// Control run the control sequence of the ctlModel: select (with value for sbo-with-enhanced-security) if required,
+// then operate
+func (c *ControlObject) Control(ctlVal MmsValue) error {
+	switch c.ControlModel() {
+	case CONTROL_MODEL_SBO_NORMAL:
+		if err := c.Select(); err != nil {
+			return err
+		}
+	case CONTROL_MODEL_SBO_ENHANCED:
+		if err := c.SelectWithValue(ctlVal); err != nil {
+			return err
+		}
+	}
+
+	return c.Operate(ctlVal)
+}
+
+// Select select the control object of a sbo-with-normal-security ctlModel by reading SBO
+func (c *ControlObject) Select() error {
+	return c.run("select", func() bool {
+		return bool(C.ControlObjectClient_select(c.control))
+	})
+}
+
+// SelectWithValue select the control object of a sbo-with-enhanced-security ctlModel by writing SBOw
+func (c *ControlObject) SelectWithValue(ctlVal MmsValue) error {
+	value, err := newMmsValue(ctlVal)
+	if err != nil {
+		return fmt.Errorf("failed to select %s, %w", c.ref, err)
+	}
+	defer C.MmsValue_delete(value)
+
+	return c.run("select", func() bool {
+		return bool(C.ControlObjectClient_selectWithValue(c.control, value))
+	})
+}
+
+// Operate write Oper. With an enhanced security ctlModel it waits for the CommandTermination of this operate, a
+// CommandTermination- is returned as ControlError. A late CommandTermination- of an earlier operate is told apart by
+// its ctlNum and ignored, a late CommandTermination+ can not be told apart since it carries no LastApplError.
+func (c *ControlObject) Operate(ctlVal MmsValue) error {
+	value, err := newMmsValue(ctlVal)
+	if err != nil {
+		return fmt.Errorf("failed to operate %s, %w", c.ref, err)
+	}
+	defer C.MmsValue_delete(value)
+
+	var enhanced bool
+	var timeout time.Duration
+	var ctlNum int
+	var previous LastApplError
+
+	err = c.run("operate", func() bool {
+		enhanced = ControlModel(C.ControlObjectClient_getControlModel(c.control)).enhancedSecurity()
+		timeout = c.terminationTimeout
+
+		ctlNum = int(c.ctlNum)
+		c.ctlNum++
+		C.setCtlNum(c.control, C.uint8_t(ctlNum))
+		previous = lastApplError(c.control)
+
+		// drop a CommandTermination of an earlier operate which was not waited for
+		select {
+		case <-c.terminations:
+		default:
+		}
+
+		return bool(C.ControlObjectClient_operate(c.control, value, 0))
+	})
+	if err != nil || !enhanced {
+		return err
+	}
+
+	return c.waitForTermination(ctlNum, previous, timeout)
+}
+
+// Cancel abort a selection or an operate of an enhanced security ctlModel which has not terminated yet
+func (c *ControlObject) Cancel() error {
+	return c.run("cancel", func() bool {
+		return bool(C.ControlObjectClient_cancel(c.control))
+	})
+}
+
+// Close release the control object, calling Close more than once is safe
+func (c *ControlObject) Close() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.control == nil {
+		return nil
+	}
+
+	// the client is closed: its Close destroys the control objects by closeControlObjects before the connection, a
+	// control object must not be destroyed without its connection, so it is left to closeControlObjects
+	if err := c.client.acquire(); err != nil {
+		return nil
+	}
+	defer c.client.release()
+
+	c.client.controlsMutex.Lock()
+	delete(c.client.controls, c)
+	c.client.controlsMutex.Unlock()
+
+	c.destroy()
+	return nil
+}
+
+// destroy release the libiec61850 control object, the caller holds the mutex
+func (c *ControlObject) destroy() {
+	controlObjectsMutex.Lock()
+	delete(controlObjects, c.id)
+	controlObjectsMutex.Unlock()
+
+	C.ControlObjectClient_destroy(c.control)
+	c.control = nil
+}
+
+// run a blocking control service, a failed service is returned with the LastApplError received for it
+func (c *ControlObject) run(operation string, service func() bool) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.control == nil {
+		return &ClientError{Op: operation, Ref: c.ref, Code: IED_ERROR_OBJECT_INVALIDATED}
+	}
+
+	if err := c.client.acquire(); err != nil {
+		return err
+	}
+	defer c.client.release()
+
+	if service() {
+		return nil
+	}
+
+	return &ControlError{
+		Op:            operation,
+		Ref:           c.ref,
+		Code:          IedError(C.ControlObjectClient_getLastError(c.control)),
+		LastApplError: lastApplError(c.control),
+	}
+}
+
+// waitForTermination wait for the CommandTermination of the operate with ctlNum. libiec61850 passes the last
+// LastApplError received to the termination handler: a CommandTermination+ comes without one, so it finds previous,
+// the LastApplError known when the operate was sent, a CommandTermination- comes with the LastApplError of its
+// operate.
+func (c *ControlObject) waitForTermination(ctlNum int, previous LastApplError, timeout time.Duration) error {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		select {
+		case applError := <-c.terminations:
+			if applError == previous {
+				return nil
+			}
+			if applError.CtlNum != ctlNum {
+				// the CommandTermination- of an earlier operate, a following CommandTermination+ finds it
+				previous = applError
+				continue
+			}
+			// CommandTermination+ carries no AddCause
+			if applError.Error == CONTROL_ERROR_NO_ERROR && applError.AddCause == ADD_CAUSE_UNKNOWN {
+				return nil
+			}
+			return &ControlError{Op: "terminate", Ref: c.ref, Code: IED_ERROR_OK, LastApplError: applError}
+		case <-timer.C:
+			return &ControlError{Op: "wait for command termination of", Ref: c.ref, Code: IED_ERROR_TIMEOUT}
+		}
+	}
+}
+
+// terminated queue the CommandTermination received on the connection thread of libiec61850, an unread one is
+// replaced
+func (c *ControlObject) terminated(applError LastApplError) {
+	for {
+		select {
+		case c.terminations <- applError:
+			return
+		default:
+		}
+
+		select {
+		case <-c.terminations:
+		default:
+		}
+	}
+}
+
+// closeControlObjects release the control objects of a closing client, called by Close before the connection is
+// destroyed
+func (client *IedClient) closeControlObjects() {
+	client.controlsMutex.Lock()
+	defer client.controlsMutex.Unlock()
+
+	for object := range client.controls {
+		object.mutex.Lock()
+		if object.control != nil {
+			object.destroy()
+		}
+		object.mutex.Unlock()
+		delete(client.controls, object)
+	}
+}
+
+func lastApplError(control C.ControlObjectClient) LastApplError {
+	applError := C.ControlObjectClient_getLastApplError(control)
+	return LastApplError{
+		CtlNum:   int(applError.ctlNum),
+		Error:    ControlLastApplError(applError.error),
+		AddCause: ControlAddCause(applError.addCause),
+	}
+}
@@ -0,0 +1,144 @@
+package iec61850
+
+// #include <iec61850_client.h>
+import "C"
+import (
+	"fmt"
+	"strings"
+	"unsafe"
+)
+
+// FCDA a functional constrained data (attribute) as member of a data set, e.g. {"LD0/MMXU1.TotW", IEC61850_FC_MX}
+type FCDA struct {
+	Ref string
+	FC  FunctionalConstraint
+}
+
+// String the reference in the form of the data set services, e.g. "LD0/MMXU1.TotW[MX]"
+func (fcda FCDA) String() string {
+	fc := C.FunctionalConstraint_toString(C.FunctionalConstraint(fcda.FC))
+	if fc == nil {
+		return fcda.Ref
+	}
+	return fcda.Ref + "[" + C.GoString(fc) + "]"
+}
+
+// ParseFCDA parse a reference in the form of the data set services, e.g. "LD0/MMXU1.TotW[MX]"
+func ParseFCDA(reference string) (FCDA, error) {
+	pos := strings.LastIndexByte(reference, '[')
+	if pos <= 0 || !strings.HasSuffix(reference, "]") {
+		return FCDA{}, fmt.Errorf("invalid FCDA reference %s", reference)
+	}
+
+	cFc := C.CString(reference[pos+1 : len(reference)-1])
+	defer C.free(unsafe.Pointer(cFc))
+
+	fc := FunctionalConstraint(C.FunctionalConstraint_fromString(cFc))
+	if fc == IEC61850_FC_NONE {
+		return FCDA{}, fmt.Errorf("invalid FCDA reference %s, unknown functional constraint", reference)
+	}
+
+	return FCDA{Ref: reference[:pos], FC: fc}, nil
+}
+
+// DataSetDirectory the members of a data set in the order of its values
+type DataSetDirectory struct {
+	Members []FCDA
+	// Deletable whether the data set can be deleted by clients
+	Deletable bool
+}
+
+// CreateDataSet create a data set at the IED. The reference is either "LD/LN.name" for a persistent data set or
+// "@name" for an association specific one, which is deleted by the IED when the connection is closed.
+func (client *IedClient) CreateDataSet(dataSetReference string, members []FCDA) error {
+	if err := client.acquire(); err != nil {
+		return err
+	}
+	defer client.release()
+
+	cDataSetReference := C.CString(dataSetReference)
+	defer C.free(unsafe.Pointer(cDataSetReference))
+
+	elements := C.LinkedList_create()
+	defer C.LinkedList_destroy(elements)
+
+	for _, member := range members {
+		// the references are released by LinkedList_destroy
+		C.LinkedList_add(elements, unsafe.Pointer(C.CString(member.String())))
+	}
+
+	var clientError C.IedClientError
+	C.IedConnection_createDataSet(client.connection, &clientError, cDataSetReference, elements)
+
+	if clientError != C.IED_ERROR_OK {
+		return newClientError("create dataset", dataSetReference, clientError)
+	}
+
+	return nil
+}
+
+// DeleteDataSet delete a data set created by a client, data sets of the SCL are not deletable
+func (client *IedClient) DeleteDataSet(dataSetReference string) error {
+	if err := client.acquire(); err != nil {
+		return err
+	}
+	defer client.release()
+
+	cDataSetReference := C.CString(dataSetReference)
+	defer C.free(unsafe.Pointer(cDataSetReference))
+
+	var clientError C.IedClientError
+	deleted := C.IedConnection_deleteDataSet(client.connection, &clientError, cDataSetReference)
+
+	if clientError != C.IED_ERROR_OK {
+		return newClientError("delete dataset", dataSetReference, clientError)
+	}
+	if !deleted {
+		return newClientError("delete dataset", dataSetReference, C.IED_ERROR_ACCESS_DENIED)
+	}
+
+	return nil
+}
+
+// GetDataSetDirectory read the members of a data set
+func (client *IedClient) GetDataSetDirectory(dataSetReference string) (*DataSetDirectory, error) {
+	if err := client.acquire(); err != nil {
+		return nil, err
+	}
+	defer client.release()
+
+	cDataSetReference := C.CString(dataSetReference)
+	defer C.free(unsafe.Pointer(cDataSetReference))
+
+	var clientError C.IedClientError
+	var deletable C.bool
+	members := C.IedConnection_getDataSetDirectory(client.connection, &clientError, cDataSetReference, &deletable)
+
+	if clientError != C.IED_ERROR_OK {
+		return nil, newClientError("get dataset directory", dataSetReference, clientError)
+	}
+
+	defer C.LinkedList_destroy(members)
+
+	directory, err := newDataSetDirectory(members, deletable)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dataset directory %s, %v", dataSetReference, err)
+	}
+
+	return directory, nil
+}
+
+// newDataSetDirectory parse the member references returned by libiec61850, the list stays owned by the caller
+func newDataSetDirectory(members C.LinkedList, deletable C.bool) (*DataSetDirectory, error) {
+	directory := &DataSetDirectory{Deletable: bool(deletable)}
+	for element := C.LinkedList_getNext(members); element != nil; element = C.LinkedList_getNext(element) {
+		reference := C.GoString((*C.char)(element.data))
+		member, err := ParseFCDA(reference)
+		if err != nil {
+			return nil, err
+		}
+		directory.Members = append(directory.Members, member)
+	}
+
+	return directory, nil
+}
@@ -0,0 +1,151 @@
+package iec61850
+
+// #include <iec61850_client.h>
+import "C"
+import (
+	"runtime"
+	"sync"
+	"unsafe"
+)
+
+// DataSetReader polls the values of a data set. The C data set is kept between the reads and updated in place, only
+// the members which changed since the last read are decoded again, into the values of the reader.
+type DataSetReader struct {
+	client            *IedClient
+	dataSetReference  string
+	cDataSetReference *C.char
+
+	mutex       sync.Mutex
+	dataSet     C.ClientDataSet
+	previous    *C.MmsValue
+	values      []MmsValue
+	clientError C.IedClientError
+	closed      bool
+}
+
+// NewDataSetReader create a reader of the data set, use Close to release it
+func (client *IedClient) NewDataSetReader(dataSetReference string) *DataSetReader {
+	reader := &DataSetReader{
+		client:            client,
+		dataSetReference:  dataSetReference,
+		cDataSetReference: C.CString(dataSetReference),
+	}
+	runtime.SetFinalizer(reader, (*DataSetReader).Close)
+	return reader
+}
+
+// Read the data set values and append the indexes of the members which changed since the last read to changed, on
+// the first read all members are changed. The values belong to the reader and are updated in place by the next Read,
+// copy them to keep them. A structured member keeps its slices as long as its size does not change.
+func (reader *DataSetReader) Read(changed []int) ([]MmsValue, []int, error) {
+	changed = changed[:0]
+
+	reader.mutex.Lock()
+	defer reader.mutex.Unlock()
+
+	if reader.closed {
+		return nil, changed, ErrReaderClosed
+	}
+
+	if err := reader.client.acquire(); err != nil {
+		return nil, changed, err
+	}
+	defer reader.client.release()
+
+	// the error is a field of the reader, a local variable passed to C escapes to the heap on every read
+	dataSet := C.IedConnection_readDataSetValues(reader.client.connection, &reader.clientError, reader.cDataSetReference, reader.dataSet)
+
+	if reader.clientError != C.IED_ERROR_OK {
+		return nil, changed, newClientError("read dataset values", reader.dataSetReference, reader.clientError)
+	}
+
+	reader.dataSet = dataSet
+
+	current := C.ClientDataSet_getValues(dataSet)
+	size := int(C.ClientDataSet_getDataSetSize(dataSet))
+
+	all := reader.previous == nil || len(reader.values) != size
+	if all {
+		reader.values = make([]MmsValue, size)
+	}
+
+	for i := 0; i < size; i++ {
+		element := C.MmsValue_getElement(current, C.int(i))
+		if !all && bool(C.MmsValue_equals(element, C.MmsValue_getElement(reader.previous, C.int(i)))) {
+			continue
+		}
+		reader.values[i] = reader.client.decodeInto(reader.values[i], element)
+		changed = append(changed, i)
+	}
+
+	if reader.previous == nil || !bool(C.MmsValue_update(reader.previous, current)) {
+		if reader.previous != nil {
+			C.MmsValue_delete(reader.previous)
+		}
+		reader.previous = C.MmsValue_clone(current)
+	}
+
+	return reader.values, changed, nil
+}
+
+// decodeInto decode the C value into the previous Go value of the member. A structure, an array, a bit string or an
+// octet string of the same size is updated in place, any other value is converted by toMmsValue.
+func (client *IedClient) decodeInto(previous MmsValue, value *C.MmsValue) MmsValue {
+	valueType := MMSType(C.MmsValue_getType(value))
+
+	switch v := previous.(type) {
+	case Structure:
+		if valueType == MMS_STRUCTURE && int(C.MmsValue_getArraySize(value)) == len(v) {
+			for i := range v {
+				v[i] = client.decodeInto(v[i], C.MmsValue_getElement(value, C.int(i)))
+			}
+			return previous
+		}
+	case Array:
+		if valueType == MMS_ARRAY && int(C.MmsValue_getArraySize(value)) == len(v.Elements) {
+			for i := range v.Elements {
+				v.Elements[i] = client.decodeInto(v.Elements[i], C.MmsValue_getElement(value, C.int(i)))
+			}
+			return previous
+		}
+	case BitString:
+		if valueType == MMS_BIT_STRING && int(C.MmsValue_getBitStringSize(value)) == v.Size {
+			for i := 0; i < v.Size; i++ {
+				v.SetBit(i, bool(C.MmsValue_getBitStringBit(value, C.int(i))))
+			}
+			return previous
+		}
+	case OctetString:
+		if valueType == MMS_OCTET_STRING && int(C.MmsValue_getOctetStringSize(value)) == len(v) {
+			copy(v, unsafe.Slice((*byte)(unsafe.Pointer(C.MmsValue_getOctetStringBuffer(value))), len(v)))
+			return previous
+		}
+	}
+
+	return client.toMmsValue(value)
+}
+
+// Close release the data set, calling Close more than once is safe
+func (reader *DataSetReader) Close() {
+	reader.mutex.Lock()
+	defer reader.mutex.Unlock()
+
+	if reader.closed {
+		return
+	}
+	reader.closed = true
+
+	if reader.dataSet != nil {
+		C.ClientDataSet_destroy(reader.dataSet)
+		reader.dataSet = nil
+	}
+	if reader.previous != nil {
+		C.MmsValue_delete(reader.previous)
+		reader.previous = nil
+	}
+	C.free(unsafe.Pointer(reader.cDataSetReference))
+	reader.cDataSetReference = nil
+	reader.values = nil
+
+	runtime.SetFinalizer(reader, nil)
+}
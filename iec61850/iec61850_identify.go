@@ -0,0 +1,97 @@
+package iec61850
+
+// #include <iec61850_client.h>
+import "C"
+
+// servicesSupportedBitSize the size of the MMS ServiceSupportOptions bit string
+const servicesSupportedBitSize = 85
+
+// The bits of the MMS ServiceSupportOptions (ISO 9506-2), see NegotiatedParameters.ServicesSupported
+const (
+	MMS_SERVICE_STATUS                             = 0
+	MMS_SERVICE_GET_NAME_LIST                      = 1
+	MMS_SERVICE_IDENTIFY                           = 2
+	MMS_SERVICE_READ                               = 4
+	MMS_SERVICE_WRITE                              = 5
+	MMS_SERVICE_GET_VARIABLE_ACCESS_ATTRIBUTES     = 6
+	MMS_SERVICE_DEFINE_NAMED_VARIABLE_LIST         = 11
+	MMS_SERVICE_GET_NAMED_VARIABLE_LIST_ATTRIBUTES = 12
+	MMS_SERVICE_DELETE_NAMED_VARIABLE_LIST         = 13
+	MMS_SERVICE_READ_JOURNAL                       = 65
+	MMS_SERVICE_FILE_OPEN                          = 72
+	MMS_SERVICE_FILE_READ                          = 73
+	MMS_SERVICE_FILE_CLOSE                         = 74
+	MMS_SERVICE_FILE_RENAME                        = 75
+	MMS_SERVICE_FILE_DELETE                        = 76
+	MMS_SERVICE_FILE_DIRECTORY                     = 77
+	MMS_SERVICE_INFORMATION_REPORT                 = 79
+	MMS_SERVICE_CONCLUDE                           = 83
+	MMS_SERVICE_CANCEL                             = 84
+)
+
+// ServerIdentity the answer of the MMS identify service
+type ServerIdentity struct {
+	VendorName string
+	ModelName  string
+	Revision   string
+}
+
+// NegotiatedParameters the parameters agreed with the server when the association was established
+type NegotiatedParameters struct {
+	MaxPduSize                int
+	DataStructureNestingLevel int
+	MaxServOutstandingCalling int
+	MaxServOutstandingCalled  int
+	// ServicesSupported the services of the server, test them with Bit(MMS_SERVICE_*)
+	ServicesSupported BitString
+}
+
+// Identify the vendor, model and revision of the server by the MMS identify service
+func (client *IedClient) Identify() (ServerIdentity, error) {
+	if err := client.acquire(); err != nil {
+		return ServerIdentity{}, err
+	}
+	defer client.release()
+
+	var mmsError C.MmsError
+	identity := C.MmsConnection_identify(C.IedConnection_getMmsConnection(client.connection), &mmsError)
+
+	if mmsError != C.MMS_ERROR_NONE || identity == nil {
+		return ServerIdentity{}, &ClientError{Op: "identify server", Code: mmsErrorToIedError(mmsError)}
+	}
+
+	defer C.MmsServerIdentity_destroy(identity)
+
+	return ServerIdentity{
+		VendorName: C.GoString(identity.vendorName),
+		ModelName:  C.GoString(identity.modelName),
+		Revision:   C.GoString(identity.revision),
+	}, nil
+}
+
+// Negotiated the parameters of the current association, e.g. to size read requests to MaxPduSize
+func (client *IedClient) Negotiated() (NegotiatedParameters, error) {
+	if err := client.acquire(); err != nil {
+		return NegotiatedParameters{}, err
+	}
+	defer client.release()
+
+	if ClientState(C.IedConnection_getState(client.connection)) != IED_STATE_CONNECTED {
+		return NegotiatedParameters{}, &ClientError{Op: "get negotiated parameters", Code: IED_ERROR_NOT_CONNECTED}
+	}
+
+	parameters := C.MmsConnection_getMmsConnectionParameters(C.IedConnection_getMmsConnection(client.connection))
+
+	servicesSupported := BitString{Size: servicesSupportedBitSize, Bits: make([]byte, len(parameters.servicesSupported))}
+	for i, b := range parameters.servicesSupported {
+		servicesSupported.Bits[i] = byte(b)
+	}
+
+	return NegotiatedParameters{
+		MaxPduSize:                int(parameters.maxPduSize),
+		DataStructureNestingLevel: int(parameters.dataStructureNestingLevel),
+		MaxServOutstandingCalling: int(parameters.maxServOutstandingCalling),
+		MaxServOutstandingCalled:  int(parameters.maxServOutstandingCalled),
+		ServicesSupported:         servicesSupported,
+	}, nil
+}
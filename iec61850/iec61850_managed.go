@@ -0,0 +1,299 @@
+package iec61850
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Backoff the delay between reconnect attempts grows from Initial by Multiplier up to Max, every delay is randomized
+// by +/- Jitter (0..1) of its value so that many clients do not reconnect to the same IED at once. The delay starts
+// from Initial again once a connection stayed up for Max, so an IED dropping every connection right after the
+// restore is not reconnected at the Initial rate.
+type Backoff struct {
+	Initial    time.Duration
+	Max        time.Duration
+	Multiplier float64
+	Jitter     float64
+}
+
+// DefaultBackoff 1s, 2s, 4s, ... up to 1 minute, randomized by 20%
+var DefaultBackoff = Backoff{
+	Initial:    time.Second,
+	Max:        time.Minute,
+	Multiplier: 2,
+	Jitter:     0.2,
+}
+
+// delay the wait time before the reconnect attempt, attempt 0 is the first retry
+func (b Backoff) delay(attempt int) time.Duration {
+	delay := float64(b.Initial)
+	if b.Multiplier > 1 {
+		delay *= math.Pow(b.Multiplier, float64(attempt))
+	}
+	if b.Max > 0 && delay > float64(b.Max) {
+		delay = float64(b.Max)
+	}
+	if b.Jitter > 0 {
+		delay += delay * b.Jitter * (2*rand.Float64() - 1)
+	}
+	return time.Duration(delay)
+}
+
+// ManagedClient keeps a connection to an IED alive. A lost connection is reconnected with a new IedClient, since a
+// closed client can not be connected again, and the OnConnect actions (e.g. data sets, reports) are restored.
+type ManagedClient struct {
+	hostname      string
+	tcpPort       int
+	backoff       Backoff
+	clientOptions []Option
+	tlsConfig     *TLSConfig
+
+	mutex    sync.RWMutex
+	client   *IedClient
+	restores []func(*IedClient) error
+
+	errMutex sync.Mutex
+	lastErr  error
+
+	cancel context.CancelFunc
+	done   chan struct{}
+	once   sync.Once
+}
+
+// ReconnectBackoff the delays between reconnect attempts, DefaultBackoff if not set
+func ReconnectBackoff(backoff Backoff) func(*ManagedClient) {
+	return func(m *ManagedClient) {
+		m.backoff = backoff
+	}
+}
+
+// ClientOptions the options of every IedClient created by the ManagedClient
+func ClientOptions(options ...Option) func(*ManagedClient) {
+	return func(m *ManagedClient) {
+		m.clientOptions = append(m.clientOptions, options...)
+	}
+}
+
+// ClientTLS secure every connection of the ManagedClient by TLS
+func ClientTLS(config *TLSConfig) func(*ManagedClient) {
+	return func(m *ManagedClient) {
+		m.tlsConfig = config
+	}
+}
+
+// NewManagedClient start connecting to hostname:tcpPort in the background, use Close to stop
+func NewManagedClient(hostname string, tcpPort int, options ...func(*ManagedClient)) *ManagedClient {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	m := &ManagedClient{
+		hostname: hostname,
+		tcpPort:  tcpPort,
+		backoff:  DefaultBackoff,
+		cancel:   cancel,
+		done:     make(chan struct{}),
+	}
+
+	for _, op := range options {
+		if op != nil {
+			op(m)
+		}
+	}
+
+	go m.run(ctx)
+
+	return m
+}
+
+// OnConnect run restore after every connect, before the connection is used by Do. The actions run in the order they
+// were added, a failing action closes the connection and the ManagedClient connects again after the backoff delay.
+// Actions added while connected run on the next connect.
+func (m *ManagedClient) OnConnect(restore func(*IedClient) error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.restores = append(m.restores, restore)
+}
+
+// CreateDataSetOnConnect create the data set after every connect. Association specific data sets ("@name") are
+// deleted by the IED when the connection is lost, an already existing data set is not an error.
+func (m *ManagedClient) CreateDataSetOnConnect(dataSetReference string, members []FCDA) {
+	m.OnConnect(func(client *IedClient) error {
+		err := client.CreateDataSet(dataSetReference, members)
+		if errors.Is(err, ErrObjectExists) {
+			return nil
+		}
+		return err
+	})
+}
+
+// EnableReportOnConnect install handler for the reports with rptId (see InstallReportHandler), set the data set of
+// the report control block and enable it after every connect
+func (m *ManagedClient) EnableReportOnConnect(rcbReference string, rptId string, dataSetReference string, handler func(Report)) {
+	m.OnConnect(func(client *IedClient) error {
+		if err := client.InstallReportHandler(rcbReference, rptId, handler); err != nil {
+			return err
+		}
+		return client.enableReport(rcbReference, dataSetReference)
+	})
+}
+
+// ResumeReportOnConnect resume the buffered report control block after every connect, see ResumeBufferedReport
+func (m *ManagedClient) ResumeReportOnConnect(rcbReference string, handler func(Report), options ...ResumeOption) {
+	m.OnConnect(func(client *IedClient) error {
+		return client.ResumeBufferedReport(rcbReference, handler, options...)
+	})
+}
+
+// Do call f with the connected client. While disconnected it fails fast with an error wrapping ErrNotConnected and
+// the error of the last connect attempt. The connection is not replaced while f is running.
+func (m *ManagedClient) Do(f func(*IedClient) error) error {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	if m.client == nil {
+		return m.notConnectedError()
+	}
+
+	return f(m.client)
+}
+
+// Connected whether Do currently has a connection to use
+func (m *ManagedClient) Connected() bool {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return m.client != nil
+}
+
+// Close stop reconnecting and close the connection, it waits for running Do calls
+func (m *ManagedClient) Close() {
+	m.once.Do(m.cancel)
+	<-m.done
+}
+
+func (m *ManagedClient) notConnectedError() error {
+	err := &ClientError{
+		Op:   "use connection to",
+		Ref:  fmt.Sprintf("%s:%d", m.hostname, m.tcpPort),
+		Code: IED_ERROR_NOT_CONNECTED,
+	}
+
+	m.errMutex.Lock()
+	lastErr := m.lastErr
+	m.errMutex.Unlock()
+
+	if lastErr != nil {
+		return fmt.Errorf("%w, last error: %v", err, lastErr)
+	}
+	return err
+}
+
+func (m *ManagedClient) setLastErr(err error) {
+	m.errMutex.Lock()
+	m.lastErr = err
+	m.errMutex.Unlock()
+}
+
+func (m *ManagedClient) run(ctx context.Context) {
+	defer close(m.done)
+
+	attempt := 0
+	for {
+		if uptime := m.serve(ctx); uptime > 0 && uptime >= m.backoff.Max {
+			attempt = 0
+		}
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		timer := time.NewTimer(m.backoff.delay(attempt))
+		attempt++
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// serve connect a new client, restore the OnConnect actions and hand the client to Do until the connection is lost.
+// It returns how long the restored connection was handed to Do, 0 if the connection was not established or restored.
+func (m *ManagedClient) serve(ctx context.Context) time.Duration {
+	client, err := m.newClient()
+	if err != nil {
+		m.setLastErr(err)
+		return 0
+	}
+	defer client.Close()
+
+	changes := client.StateChanges()
+
+	if err := client.ConnectContext(ctx, m.hostname, m.tcpPort); err != nil {
+		m.setLastErr(err)
+		return 0
+	}
+
+	m.mutex.Lock()
+	restores := m.restores
+	m.mutex.Unlock()
+
+	for _, restore := range restores {
+		if err := restore(client); err != nil {
+			m.setLastErr(err)
+			return 0
+		}
+	}
+
+	m.mutex.Lock()
+	m.client = client
+	m.mutex.Unlock()
+
+	connected := time.Now()
+
+	defer func() {
+		m.mutex.Lock()
+		m.client = nil
+		m.mutex.Unlock()
+	}()
+
+	m.setLastErr(nil)
+
+	for client.State() == IED_STATE_CONNECTED {
+		select {
+		case <-ctx.Done():
+			return time.Since(connected)
+		case change := <-changes:
+			if change.State == IED_STATE_CLOSED {
+				m.setLastErr(&ClientError{
+					Op:   "keep connection to",
+					Ref:  fmt.Sprintf("%s:%d", m.hostname, m.tcpPort),
+					Code: IED_ERROR_CONNECTION_LOST,
+				})
+				return time.Since(connected)
+			}
+		}
+	}
+
+	return time.Since(connected)
+}
+
+func (m *ManagedClient) newClient() (*IedClient, error) {
+	if m.tlsConfig != nil {
+		return NewIedClientWithTLS(m.tlsConfig, m.clientOptions...)
+	}
+	return NewIedClient(m.clientOptions...), nil
+}
+
+// enableReport set DatSet and RptEna of a buffered or unbuffered report control block with a single request
+func (client *IedClient) enableReport(rcbReference string, dataSetReference string) error {
+	rcb := &RCBValues{Ref: rcbReference, DatSet: dataSetReference, RptEna: true}
+	return client.SetRCBValues(rcb, RCB_ELEMENT_DATSET|RCB_ELEMENT_RPT_ENA, true)
+}
@@ -0,0 +1,227 @@
+package iec61850
+
+// #include <iec61850_client.h>
+import "C"
+import (
+	"fmt"
+	"strings"
+	"unsafe"
+)
+
+// defaultMaxVariablesPerRead keeps a single read request well below the usual negotiated PDU sizes
+const defaultMaxVariablesPerRead = 64
+
+// ObjectRef a functional constrained data (attribute) reference, e.g. {"LD0/MMXU1.TotW.mag.f", IEC61850_FC_MX}
+type ObjectRef struct {
+	Ref string
+	FC  FunctionalConstraint
+}
+
+type ReadResult struct {
+	ObjectRef
+	Value MmsValue
+	Err   error
+}
+
+// MaxVariablesPerRead limit the number of variables ReadMany puts into one MMS read request
+func MaxVariablesPerRead(count int) func(*IedClient) {
+	return func(c *IedClient) {
+		c.maxVariablesPerRead = count
+	}
+}
+
+// ReadMany read all references with as few MMS read requests as possible. References are grouped by logical device,
+// every group is read with MMS read requests carrying a list of variables. The results are in the order of refs,
+// a failed request or a data access error only sets the Err of the affected results.
+func (client *IedClient) ReadMany(refs []ObjectRef) []ReadResult {
+	results := make([]ReadResult, len(refs))
+	itemIds := make([]string, len(refs))
+
+	groups := make(map[string][]int)
+	var domains []string
+
+	for i, ref := range refs {
+		results[i].ObjectRef = ref
+
+		domainId, itemId, err := toMmsVariableName("read object", ref)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+
+		if _, ok := groups[domainId]; !ok {
+			domains = append(domains, domainId)
+		}
+		groups[domainId] = append(groups[domainId], i)
+		itemIds[i] = itemId
+	}
+
+	batchSize := client.maxVariablesPerRead
+	if batchSize <= 0 {
+		batchSize = defaultMaxVariablesPerRead
+	}
+
+	for _, domainId := range domains {
+		indexes := groups[domainId]
+		for start := 0; start < len(indexes); start += batchSize {
+			end := start + batchSize
+			if end > len(indexes) {
+				end = len(indexes)
+			}
+			client.readMultipleVariables(domainId, indexes[start:end], itemIds, results)
+		}
+	}
+
+	return results
+}
+
+func (client *IedClient) readMultipleVariables(domainId string, indexes []int, itemIds []string, results []ReadResult) {
+	if err := client.acquire(); err != nil {
+		for _, index := range indexes {
+			results[index].Err = err
+		}
+		return
+	}
+	defer client.release()
+
+	cDomainId := C.CString(domainId)
+	defer C.free(unsafe.Pointer(cDomainId))
+
+	items := C.LinkedList_create()
+	defer C.LinkedList_destroy(items)
+
+	for _, index := range indexes {
+		// the item ids are released by LinkedList_destroy
+		C.LinkedList_add(items, unsafe.Pointer(C.CString(itemIds[index])))
+	}
+
+	var mmsError C.MmsError
+	values := C.MmsConnection_readMultipleVariables(C.IedConnection_getMmsConnection(client.connection), &mmsError, cDomainId, items)
+
+	if mmsError != C.MMS_ERROR_NONE || values == nil {
+		clientError := mmsErrorToIedError(mmsError)
+		for _, index := range indexes {
+			results[index].Err = &ClientError{Op: "read object", Ref: results[index].Ref, Code: clientError}
+		}
+		return
+	}
+
+	defer C.MmsValue_delete(values)
+
+	size := int(C.MmsValue_getArraySize(values))
+	for i, index := range indexes {
+		if i >= size {
+			results[index].Err = fmt.Errorf("failed to read object %s, no result received", results[index].Ref)
+			continue
+		}
+
+		value := client.toMmsValue(C.MmsValue_getElement(values, C.int(i)))
+		if accessError, ok := value.(DataAccessError); ok {
+			results[index].Err = &ClientError{Op: "read object", Ref: results[index].Ref, Code: dataAccessErrorToIedError(accessError)}
+			continue
+		}
+
+		results[index].Value = client.decodeNamed(results[index].Ref, value)
+	}
+}
+
+// writeMultipleVariables write the components of one logical device with a single MMS write request, the error
+// names the first component the server rejected
+func (client *IedClient) writeMultipleVariables(refs []ObjectRef, values []MmsValue) error {
+	var domainId string
+	itemIds := make([]string, len(refs))
+	for i, ref := range refs {
+		domain, itemId, err := toMmsVariableName("write object", ref)
+		if err != nil {
+			return err
+		}
+		if i > 0 && domain != domainId {
+			return fmt.Errorf("failed to write object %s, not in logical device %s", ref.Ref, domainId)
+		}
+		domainId, itemIds[i] = domain, itemId
+	}
+
+	items := C.LinkedList_create()
+	defer C.LinkedList_destroy(items)
+	mmsValues := C.LinkedList_create()
+	defer C.LinkedList_destroyStatic(mmsValues)
+
+	for i, value := range values {
+		mmsValue, err := newMmsValue(value)
+		if err != nil {
+			return fmt.Errorf("failed to write object %s, %w", refs[i].Ref, err)
+		}
+		defer C.MmsValue_delete(mmsValue)
+
+		// the item ids are released by LinkedList_destroy, the values by the deferred MmsValue_delete
+		C.LinkedList_add(items, unsafe.Pointer(C.CString(itemIds[i])))
+		C.LinkedList_add(mmsValues, unsafe.Pointer(mmsValue))
+	}
+
+	if err := client.acquire(); err != nil {
+		return err
+	}
+	defer client.release()
+
+	cDomainId := C.CString(domainId)
+	defer C.free(unsafe.Pointer(cDomainId))
+
+	var mmsError C.MmsError
+	var accessResults C.LinkedList
+	C.MmsConnection_writeMultipleVariables(C.IedConnection_getMmsConnection(client.connection), &mmsError, cDomainId,
+		items, mmsValues, &accessResults)
+
+	if accessResults != nil {
+		defer func() {
+			for element := C.LinkedList_getNext(accessResults); element != nil; element = C.LinkedList_getNext(element) {
+				C.MmsValue_delete((*C.MmsValue)(element.data))
+			}
+			C.LinkedList_destroyStatic(accessResults)
+		}()
+	}
+
+	if mmsError != C.MMS_ERROR_NONE {
+		return &ClientError{Op: "write object", Ref: refs[0].Ref, Code: mmsErrorToIedError(mmsError)}
+	}
+
+	i := 0
+	for element := C.LinkedList_getNext(accessResults); element != nil && i < len(refs); element = C.LinkedList_getNext(element) {
+		result := (*C.MmsValue)(element.data)
+		if result != nil && C.MmsValue_getType(result) == C.MMS_DATA_ACCESS_ERROR {
+			accessError := DataAccessError(C.MmsValue_getDataAccessError(result))
+			if code := dataAccessErrorToIedError(accessError); code != IED_ERROR_OK {
+				return &ClientError{Op: "write object", Ref: refs[i].Ref, Code: code}
+			}
+		}
+		i++
+	}
+
+	return nil
+}
+
+// toMmsVariableName convert "LD/LN.DO.DA" with its FC to the MMS domain "LD" and item "LN$FC$DO$DA", op names the
+// operation of the caller in the error
+func toMmsVariableName(op string, ref ObjectRef) (string, string, error) {
+	pos := strings.IndexByte(ref.Ref, '/')
+	if pos <= 0 || pos == len(ref.Ref)-1 {
+		return "", "", &ClientError{Op: op, Ref: ref.Ref, Code: IED_ERROR_OBJECT_REFERENCE_INVALID}
+	}
+
+	fc := C.FunctionalConstraint_toString(C.FunctionalConstraint(ref.FC))
+	if fc == nil {
+		return "", "", fmt.Errorf("failed to %s %s, invalid functional constraint: %d", op, ref.Ref, ref.FC)
+	}
+
+	names := strings.Split(ref.Ref[pos+1:], ".")
+
+	var builder strings.Builder
+	builder.WriteString(names[0])
+	builder.WriteString("$")
+	builder.WriteString(C.GoString(fc))
+	for _, name := range names[1:] {
+		builder.WriteString("$")
+		builder.WriteString(name)
+	}
+
+	return ref.Ref[:pos], builder.String(), nil
+}
@@ -0,0 +1,307 @@
+package iec61850
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// tagName the struct tag holding the component path below the object, e.g. `iec61850:"mag.f"` or `iec61850:"phsA.cVal.mag.f"`
+const tagName = "iec61850"
+
+var (
+	mmsValueType  = reflect.TypeOf((*MmsValue)(nil)).Elem()
+	qualityType   = reflect.TypeOf(Quality(0))
+	timestampType = reflect.TypeOf(Timestamp{})
+	timeType      = reflect.TypeOf(time.Time{})
+)
+
+// ReadInto read a whole data object in one request and set every tagged field of the struct v points to.
+// The components are matched by name using the variable specification of the object, not by position.
+func (client *IedClient) ReadInto(objectRef string, constraint FunctionalConstraint, v interface{}) error {
+	target := reflect.ValueOf(v)
+	if target.Kind() != reflect.Ptr || target.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("failed to read object %s, expect pointer to struct, got %T", objectRef, v)
+	}
+
+	values, err := client.readFlattened(objectRef, constraint)
+	if err != nil {
+		return err
+	}
+
+	if err := readStruct(values, "", target.Elem()); err != nil {
+		return fmt.Errorf("failed to read object %s, %v", objectRef, err)
+	}
+
+	return nil
+}
+
+// WriteFrom write every tagged field of the struct v to its component of the data object. Only the tagged components
+// are written, all of them with one MMS write request, so the other components (e.g. q and t) keep the value the
+// server has. Components inside arrays can not be written by WriteFrom. The error names the first component the
+// server rejected.
+func (client *IedClient) WriteFrom(objectRef string, constraint FunctionalConstraint, v interface{}) error {
+	source := reflect.ValueOf(v)
+	if source.Kind() == reflect.Ptr {
+		source = source.Elem()
+	}
+	if source.Kind() != reflect.Struct {
+		return fmt.Errorf("failed to write object %s, expect struct, got %T", objectRef, v)
+	}
+
+	spec, err := client.getVariableSpec(objectRef, constraint)
+	if err != nil {
+		return err
+	}
+
+	var components []ObjectRef
+	var values []MmsValue
+	err = writeStruct(spec, "", source, func(path string, value MmsValue) {
+		components = append(components, ObjectRef{Ref: objectRef + "." + path, FC: constraint})
+		values = append(values, value)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write object %s, %v", objectRef, err)
+	}
+	if len(components) == 0 {
+		return nil
+	}
+
+	return client.writeMultipleVariables(components, values)
+}
+
+func fieldPath(prefix string, field reflect.StructField) (string, bool) {
+	tag, ok := field.Tag.Lookup(tagName)
+	if !ok || tag == "-" || !field.IsExported() {
+		return "", false
+	}
+	return joinPath(prefix, tag), true
+}
+
+// isLeafType types which are set from a single value instead of being walked as nested struct
+func isLeafType(typ reflect.Type) bool {
+	return typ == qualityType || typ == timestampType || typ == timeType || typ == mmsValueType
+}
+
+func readStruct(values map[string]MmsValue, prefix string, target reflect.Value) error {
+	for i := 0; i < target.NumField(); i++ {
+		field := target.Type().Field(i)
+		path, ok := fieldPath(prefix, field)
+		if !ok {
+			continue
+		}
+
+		if field.Type.Kind() == reflect.Struct && !isLeafType(field.Type) {
+			if err := readStruct(values, path, target.Field(i)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		value, ok := values[path]
+		if !ok {
+			return fmt.Errorf("component %s not found", path)
+		}
+
+		if err := setField(target.Field(i), value); err != nil {
+			return fmt.Errorf("component %s: %v", path, err)
+		}
+	}
+
+	return nil
+}
+
+func setField(field reflect.Value, value MmsValue) error {
+	switch field.Type() {
+	case mmsValueType:
+		field.Set(reflect.ValueOf(&value).Elem())
+		return nil
+	case qualityType:
+		if q, ok := qualityValue(value); ok {
+			field.Set(reflect.ValueOf(q))
+			return nil
+		}
+	case timestampType:
+		switch value.(type) {
+		case Timestamp, UtcTime:
+			field.Set(reflect.ValueOf(toTimestamp(value)))
+			return nil
+		}
+	case timeType:
+		switch v := value.(type) {
+		case Timestamp, UtcTime:
+			field.Set(reflect.ValueOf(toTimestamp(value).Time()))
+			return nil
+		case BinaryTime:
+			field.Set(reflect.ValueOf(v.Time()))
+			return nil
+		}
+	}
+
+	switch field.Kind() {
+	case reflect.Bool:
+		if v, ok := value.(Boolean); ok {
+			field.SetBool(bool(v))
+			return nil
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		switch v := value.(type) {
+		case Integer:
+			field.SetInt(int64(v))
+			return nil
+		case Unsigned:
+			field.SetInt(int64(v))
+			return nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		switch v := value.(type) {
+		case Unsigned:
+			field.SetUint(uint64(v))
+			return nil
+		case Integer:
+			field.SetUint(uint64(v))
+			return nil
+		case BitString:
+			field.SetUint(uint64(v.Uint32()))
+			return nil
+		case Quality:
+			field.SetUint(uint64(v.Bits()))
+			return nil
+		}
+	case reflect.Float32, reflect.Float64:
+		if f, ok := toFloat64(value); ok {
+			field.SetFloat(f)
+			return nil
+		}
+	case reflect.String:
+		switch v := value.(type) {
+		case VisibleString:
+			field.SetString(string(v))
+			return nil
+		case String:
+			field.SetString(string(v))
+			return nil
+		}
+	case reflect.Slice:
+		if v, ok := value.(OctetString); ok && field.Type().Elem().Kind() == reflect.Uint8 {
+			field.SetBytes(append([]byte(nil), v...))
+			return nil
+		}
+	}
+
+	return fmt.Errorf("can not set %T to field of type %s", value, field.Type())
+}
+
+func writeStruct(spec *MmsVariableSpecification, prefix string, source reflect.Value, write func(string, MmsValue)) error {
+	for i := 0; i < source.NumField(); i++ {
+		field := source.Type().Field(i)
+		path, ok := fieldPath(prefix, field)
+		if !ok {
+			continue
+		}
+
+		if field.Type.Kind() == reflect.Struct && !isLeafType(field.Type) {
+			if err := writeStruct(spec, path, source.Field(i), write); err != nil {
+				return err
+			}
+			continue
+		}
+
+		component, err := lookupComponentSpec(spec, path)
+		if err != nil {
+			return err
+		}
+
+		value, err := fieldToMmsValue(source.Field(i), component)
+		if err != nil {
+			return fmt.Errorf("component %s: %v", path, err)
+		}
+
+		write(path, value)
+	}
+
+	return nil
+}
+
+// lookupComponentSpec return the specification of the component at path, e.g. "mag.f"
+func lookupComponentSpec(spec *MmsVariableSpecification, path string) (*MmsVariableSpecification, error) {
+	if strings.IndexByte(path, '(') >= 0 {
+		return nil, fmt.Errorf("component %s is an array element", path)
+	}
+
+	for _, name := range strings.Split(path, ".") {
+		if spec = spec.Child(name); spec == nil {
+			return nil, fmt.Errorf("component %s not found", path)
+		}
+	}
+
+	return spec, nil
+}
+
+// fieldToMmsValue convert a field to a value of the MMS type of the component
+func fieldToMmsValue(field reflect.Value, spec *MmsVariableSpecification) (MmsValue, error) {
+	if field.Type() == mmsValueType {
+		if field.IsNil() {
+			return nil, fmt.Errorf("nil value")
+		}
+		return field.Interface().(MmsValue), nil
+	}
+
+	switch spec.Type {
+	case MMS_BOOLEAN:
+		if field.Kind() == reflect.Bool {
+			return Boolean(field.Bool()), nil
+		}
+	case MMS_INTEGER:
+		switch field.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return Integer(field.Int()), nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return Integer(field.Uint()), nil
+		}
+	case MMS_UNSIGNED:
+		switch field.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return Unsigned(field.Int()), nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return Unsigned(field.Uint()), nil
+		}
+	case MMS_FLOAT:
+		if field.Kind() == reflect.Float32 || field.Kind() == reflect.Float64 {
+			if spec.FloatWidth == 64 {
+				return Float64(field.Float()), nil
+			}
+			return Float32(field.Float()), nil
+		}
+	case MMS_VISIBLE_STRING:
+		if field.Kind() == reflect.String {
+			return VisibleString(field.String()), nil
+		}
+	case MMS_STRING:
+		if field.Kind() == reflect.String {
+			return String(field.String()), nil
+		}
+	case MMS_OCTET_STRING:
+		if field.Kind() == reflect.Slice && field.Type().Elem().Kind() == reflect.Uint8 {
+			return OctetString(append([]byte(nil), field.Bytes()...)), nil
+		}
+	case MMS_BIT_STRING:
+		if field.Type() == qualityType {
+			return field.Interface().(Quality), nil
+		}
+		switch field.Kind() {
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return NewBitString(spec.Size, uint32(field.Uint())), nil
+		}
+	case MMS_UTC_TIME:
+		switch field.Type() {
+		case timestampType:
+			return field.Interface().(Timestamp), nil
+		case timeType:
+			return NewTimestamp(field.Interface().(time.Time)), nil
+		}
+	}
+
+	return nil, fmt.Errorf("can not write field of type %s to %v", field.Type(), spec.Type)
+}
@@ -0,0 +1,514 @@
+package iec61850
+
+/*
+#include <stdint.h>
+#include <iec61850_client.h>
+
+extern void goReportHandler(void* parameter, ClientReport report);
+
+static void installReportHandler(IedConnection self, const char* rcbReference, const char* rptId, uintptr_t id)
+{
+	IedConnection_installReportHandler(self, rcbReference, rptId, goReportHandler, (void*) id);
+}
+*/
+import "C"
+import (
+	"strings"
+	"sync"
+	"time"
+	"unsafe"
+)
+
+// TriggerOptions the TrgOps of a report control block, the events which cause a report
+type TriggerOptions int
+
+const (
+	TRG_OPT_DATA_CHANGED    TriggerOptions = C.TRG_OPT_DATA_CHANGED
+	TRG_OPT_QUALITY_CHANGED TriggerOptions = C.TRG_OPT_QUALITY_CHANGED
+	TRG_OPT_DATA_UPDATE     TriggerOptions = C.TRG_OPT_DATA_UPDATE
+	TRG_OPT_INTEGRITY       TriggerOptions = C.TRG_OPT_INTEGRITY
+	TRG_OPT_GI              TriggerOptions = C.TRG_OPT_GI
+)
+
+// ReportOptions the OptFlds of a report control block, the optional fields included in a report
+type ReportOptions int
+
+const (
+	RPT_OPT_SEQ_NUM              ReportOptions = C.RPT_OPT_SEQ_NUM
+	RPT_OPT_TIME_STAMP           ReportOptions = C.RPT_OPT_TIME_STAMP
+	RPT_OPT_REASON_FOR_INCLUSION ReportOptions = C.RPT_OPT_REASON_FOR_INCLUSION
+	RPT_OPT_DATA_SET             ReportOptions = C.RPT_OPT_DATA_SET
+	RPT_OPT_DATA_REFERENCE       ReportOptions = C.RPT_OPT_DATA_REFERENCE
+	RPT_OPT_BUFFER_OVERFLOW      ReportOptions = C.RPT_OPT_BUFFER_OVERFLOW
+	RPT_OPT_ENTRY_ID             ReportOptions = C.RPT_OPT_ENTRY_ID
+	RPT_OPT_CONF_REV             ReportOptions = C.RPT_OPT_CONF_REV
+)
+
+// ReasonForInclusion why a data set member is included in a report
+type ReasonForInclusion int
+
+const (
+	REASON_NOT_INCLUDED   ReasonForInclusion = C.IEC61850_REASON_NOT_INCLUDED
+	REASON_DATA_CHANGE    ReasonForInclusion = C.IEC61850_REASON_DATA_CHANGE
+	REASON_QUALITY_CHANGE ReasonForInclusion = C.IEC61850_REASON_QUALITY_CHANGE
+	REASON_DATA_UPDATE    ReasonForInclusion = C.IEC61850_REASON_DATA_UPDATE
+	REASON_INTEGRITY      ReasonForInclusion = C.IEC61850_REASON_INTEGRITY
+	REASON_GI             ReasonForInclusion = C.IEC61850_REASON_GI
+	// REASON_UNKNOWN the member is included, but the report does not carry the reason
+	REASON_UNKNOWN ReasonForInclusion = C.IEC61850_REASON_UNKNOWN
+)
+
+// Has whether the reason contains r, a member can be included for more than one reason
+func (reason ReasonForInclusion) Has(r ReasonForInclusion) bool {
+	return reason&r != 0
+}
+
+// String the abbreviations of IEC 61850, e.g. "dchg" or "dchg|qchg"
+func (reason ReasonForInclusion) String() string {
+	if reason == REASON_NOT_INCLUDED {
+		return "not-included"
+	}
+
+	var names []string
+	for _, r := range []struct {
+		reason ReasonForInclusion
+		name   string
+	}{
+		{REASON_DATA_CHANGE, "dchg"},
+		{REASON_QUALITY_CHANGE, "qchg"},
+		{REASON_DATA_UPDATE, "dupd"},
+		{REASON_INTEGRITY, "integrity"},
+		{REASON_GI, "GI"},
+		{REASON_UNKNOWN, "unknown"},
+	} {
+		if reason.Has(r.reason) {
+			names = append(names, r.name)
+		}
+	}
+
+	return strings.Join(names, "|")
+}
+
+// RCBElement a mask of the report control block attributes written by SetRCBValues
+type RCBElement uint32
+
+const (
+	RCB_ELEMENT_RPT_ID        RCBElement = C.RCB_ELEMENT_RPT_ID
+	RCB_ELEMENT_RPT_ENA       RCBElement = C.RCB_ELEMENT_RPT_ENA
+	RCB_ELEMENT_RESV          RCBElement = C.RCB_ELEMENT_RESV
+	RCB_ELEMENT_DATSET        RCBElement = C.RCB_ELEMENT_DATSET
+	RCB_ELEMENT_CONF_REV      RCBElement = C.RCB_ELEMENT_CONF_REV
+	RCB_ELEMENT_OPT_FLDS      RCBElement = C.RCB_ELEMENT_OPT_FLDS
+	RCB_ELEMENT_BUF_TM        RCBElement = C.RCB_ELEMENT_BUF_TM
+	RCB_ELEMENT_SQ_NUM        RCBElement = C.RCB_ELEMENT_SQ_NUM
+	RCB_ELEMENT_TRG_OPS       RCBElement = C.RCB_ELEMENT_TRG_OPS
+	RCB_ELEMENT_INTG_PD       RCBElement = C.RCB_ELEMENT_INTG_PD
+	RCB_ELEMENT_GI            RCBElement = C.RCB_ELEMENT_GI
+	RCB_ELEMENT_PURGE_BUF     RCBElement = C.RCB_ELEMENT_PURGE_BUF
+	RCB_ELEMENT_ENTRY_ID      RCBElement = C.RCB_ELEMENT_ENTRY_ID
+	RCB_ELEMENT_TIME_OF_ENTRY RCBElement = C.RCB_ELEMENT_TIME_OF_ENTRY
+	RCB_ELEMENT_RESV_TMS      RCBElement = C.RCB_ELEMENT_RESV_TMS
+	RCB_ELEMENT_OWNER         RCBElement = C.RCB_ELEMENT_OWNER
+)
+
+// RCBValues the attributes of a buffered (BRCB) or unbuffered (URCB) report control block. Resv is only used by
+// URCBs, PurgeBuf, EntryID and EntryTime only by BRCBs, ResvTms by BRCBs and by URCBs of edition 2.
+type RCBValues struct {
+	// Ref the object reference, e.g. "LD0/LLN0.RP.urcbA01" or "LD0/LLN0.BR.brcbA01"
+	Ref      string
+	Buffered bool
+	RptID    string
+	RptEna   bool
+	Resv     bool
+	// DatSet the data set reference in MMS syntax, e.g. "LD0/LLN0$Events"
+	DatSet  string
+	ConfRev uint32
+	OptFlds ReportOptions
+	// BufTm the buffer time in milliseconds
+	BufTm  uint32
+	SqNum  uint16
+	TrgOps TriggerOptions
+	// IntgPd the integrity period in milliseconds
+	IntgPd   uint32
+	GI       bool
+	PurgeBuf bool
+	// HasResvTms whether the report control block has ResvTms, the reservation time in seconds
+	HasResvTms bool
+	ResvTms    int16
+	EntryID    []byte
+	// EntryTime the time of the last buffered entry
+	EntryTime time.Time
+	// Owner the IP address of the client which reserved or enabled the report control block, empty if none
+	Owner []byte
+}
+
+// GetRCBValues read all attributes of a report control block with a single request
+func (client *IedClient) GetRCBValues(rcbReference string) (*RCBValues, error) {
+	if err := client.acquire(); err != nil {
+		return nil, err
+	}
+	defer client.release()
+
+	cRcbReference := C.CString(rcbReference)
+	defer C.free(unsafe.Pointer(cRcbReference))
+
+	var clientError C.IedClientError
+	rcb := C.IedConnection_getRCBValues(client.connection, &clientError, cRcbReference, nil)
+
+	if clientError != C.IED_ERROR_OK {
+		return nil, newClientError("read report control block", rcbReference, clientError)
+	}
+
+	defer C.ClientReportControlBlock_destroy(rcb)
+
+	values := &RCBValues{
+		Ref:        rcbReference,
+		Buffered:   bool(C.ClientReportControlBlock_isBuffered(rcb)),
+		RptID:      cStringToGoString(C.ClientReportControlBlock_getRptId(rcb)),
+		RptEna:     bool(C.ClientReportControlBlock_getRptEna(rcb)),
+		Resv:       bool(C.ClientReportControlBlock_getResv(rcb)),
+		DatSet:     cStringToGoString(C.ClientReportControlBlock_getDataSetReference(rcb)),
+		ConfRev:    uint32(C.ClientReportControlBlock_getConfRev(rcb)),
+		OptFlds:    ReportOptions(C.ClientReportControlBlock_getOptFlds(rcb)),
+		BufTm:      uint32(C.ClientReportControlBlock_getBufTm(rcb)),
+		SqNum:      uint16(C.ClientReportControlBlock_getSqNum(rcb)),
+		TrgOps:     TriggerOptions(C.ClientReportControlBlock_getTrgOps(rcb)),
+		IntgPd:     uint32(C.ClientReportControlBlock_getIntgPd(rcb)),
+		GI:         bool(C.ClientReportControlBlock_getGI(rcb)),
+		PurgeBuf:   bool(C.ClientReportControlBlock_getPurgeBuf(rcb)),
+		HasResvTms: bool(C.ClientReportControlBlock_hasResvTms(rcb)),
+		ResvTms:    int16(C.ClientReportControlBlock_getResvTms(rcb)),
+		EntryID:    octetStringBytes(C.ClientReportControlBlock_getEntryId(rcb)),
+		Owner:      octetStringBytes(C.ClientReportControlBlock_getOwner(rcb)),
+	}
+
+	if entryTime := uint64(C.ClientReportControlBlock_getEntryTime(rcb)); entryTime != 0 {
+		values.EntryTime = time.UnixMilli(int64(entryTime))
+	}
+
+	return values, nil
+}
+
+// SetRCBValues write the attributes of the report control block selected by mask, e.g.
+// RCB_ELEMENT_DATSET|RCB_ELEMENT_RPT_ENA. With singleRequest all attributes are written with one request, otherwise
+// one request per attribute is used, which some IEDs require. RptEna and GI are written after the other attributes.
+func (client *IedClient) SetRCBValues(values *RCBValues, mask RCBElement, singleRequest bool) error {
+	if err := client.acquire(); err != nil {
+		return err
+	}
+	defer client.release()
+
+	cRcbReference := C.CString(values.Ref)
+	defer C.free(unsafe.Pointer(cRcbReference))
+
+	rcb := C.ClientReportControlBlock_create(cRcbReference)
+	defer C.ClientReportControlBlock_destroy(rcb)
+
+	if mask&RCB_ELEMENT_RPT_ID != 0 {
+		cRptId := C.CString(values.RptID)
+		defer C.free(unsafe.Pointer(cRptId))
+		C.ClientReportControlBlock_setRptId(rcb, cRptId)
+	}
+	if mask&RCB_ELEMENT_DATSET != 0 {
+		cDataSetReference := C.CString(values.DatSet)
+		defer C.free(unsafe.Pointer(cDataSetReference))
+		C.ClientReportControlBlock_setDataSetReference(rcb, cDataSetReference)
+	}
+	if mask&RCB_ELEMENT_ENTRY_ID != 0 {
+		entryId := C.MmsValue_newOctetString(C.int(len(values.EntryID)), C.int(len(values.EntryID)))
+		defer C.MmsValue_delete(entryId)
+		if len(values.EntryID) > 0 {
+			C.MmsValue_setOctetString(entryId, (*C.uint8_t)(unsafe.Pointer(&values.EntryID[0])), C.int(len(values.EntryID)))
+		}
+		C.ClientReportControlBlock_setEntryId(rcb, entryId)
+	}
+
+	C.ClientReportControlBlock_setRptEna(rcb, C.bool(values.RptEna))
+	C.ClientReportControlBlock_setResv(rcb, C.bool(values.Resv))
+	C.ClientReportControlBlock_setOptFlds(rcb, C.int(values.OptFlds))
+	C.ClientReportControlBlock_setBufTm(rcb, C.uint32_t(values.BufTm))
+	C.ClientReportControlBlock_setTrgOps(rcb, C.int(values.TrgOps))
+	C.ClientReportControlBlock_setIntgPd(rcb, C.uint32_t(values.IntgPd))
+	C.ClientReportControlBlock_setGI(rcb, C.bool(values.GI))
+	C.ClientReportControlBlock_setPurgeBuf(rcb, C.bool(values.PurgeBuf))
+	C.ClientReportControlBlock_setResvTms(rcb, C.int16_t(values.ResvTms))
+
+	var clientError C.IedClientError
+	C.IedConnection_setRCBValues(client.connection, &clientError, rcb, C.uint32_t(mask), C.bool(singleRequest))
+
+	if clientError != C.IED_ERROR_OK {
+		return newClientError("write report control block", values.Ref, clientError)
+	}
+
+	return nil
+}
+
+// Report a received report, only the members included in the report have a value
+type Report struct {
+	RcbReference string
+	RptID        string
+	// DataSetName the data set reference, empty if the report does not carry it
+	DataSetName string
+
+	HasSeqNum          bool
+	SeqNum             uint16
+	SubSeqNum          uint16
+	MoreSegmentsFollow bool
+
+	// EntryID the entry of a buffered report, nil if the report does not carry it
+	EntryID []byte
+	// BufOvfl whether the buffer of the BRCB overflowed and entries were lost before this report
+	BufOvfl    bool
+	HasConfRev bool
+	ConfRev    uint32
+	// Timestamp the time the report was created, zero if the report does not carry it
+	Timestamp time.Time
+
+	// Values and Reasons per data set member in the order of the data set, the value of a member not included is nil
+	Values  []MmsValue
+	Reasons []ReasonForInclusion
+	// DataReferences the references of the members, nil if the report does not carry them
+	DataReferences []string
+
+	// withoutTimestamps the report was received by a client with WithoutTimestamps, ExplainReport keeps q raw
+	withoutTimestamps bool
+}
+
+// Included whether the member at index is included in the report
+func (report *Report) Included(index int) bool {
+	return index >= 0 && index < len(report.Reasons) && report.Reasons[index] != REASON_NOT_INCLUDED
+}
+
+// reportSubscription queues the reports received on the connection thread of libiec61850 and calls the handler from
+// its own goroutine, so the handler may call the client
+type reportSubscription struct {
+	id           uintptr
+	client       *IedClient
+	rcbReference string
+	handler      func(Report)
+
+	mutex   sync.Mutex
+	cond    *sync.Cond
+	pending []Report
+	stopped bool
+}
+
+var (
+	reportSubscriptionsMutex sync.Mutex
+	reportSubscriptions      = make(map[uintptr]*reportSubscription)
+	reportSubscriptionId     uintptr
+)
+
+func lookupReportSubscription(id uintptr) *reportSubscription {
+	reportSubscriptionsMutex.Lock()
+	defer reportSubscriptionsMutex.Unlock()
+
+	return reportSubscriptions[id]
+}
+
+// InstallReportHandler call handler for every report of the report control block. The rptId identifies the reports,
+// if empty the reports are identified by rcbReference. An earlier handler of the report control block is replaced.
+// Install the handler before enabling the report control block and again when its data set changes. The handler is
+// called from a goroutine of the subscription, one report after another. Until UninstallReportHandler or Close the
+// handler keeps the client alive.
+func (client *IedClient) InstallReportHandler(rcbReference string, rptId string, handler func(Report)) error {
+	if err := client.acquire(); err != nil {
+		return err
+	}
+	defer client.release()
+
+	subscription := &reportSubscription{
+		client:       client,
+		rcbReference: rcbReference,
+		handler:      handler,
+	}
+	subscription.cond = sync.NewCond(&subscription.mutex)
+
+	reportSubscriptionsMutex.Lock()
+	reportSubscriptionId++
+	subscription.id = reportSubscriptionId
+	reportSubscriptions[subscription.id] = subscription
+	reportSubscriptionsMutex.Unlock()
+
+	go subscription.dispatch()
+
+	cRcbReference := C.CString(rcbReference)
+	defer C.free(unsafe.Pointer(cRcbReference))
+
+	var cRptId *C.char
+	if rptId != "" {
+		cRptId = C.CString(rptId)
+		defer C.free(unsafe.Pointer(cRptId))
+	}
+
+	client.reportsMutex.Lock()
+	defer client.reportsMutex.Unlock()
+
+	C.installReportHandler(client.connection, cRcbReference, cRptId, C.uintptr_t(subscription.id))
+
+	if previous, ok := client.reports[rcbReference]; ok {
+		previous.stop()
+	}
+	if client.reports == nil {
+		client.reports = make(map[string]*reportSubscription)
+	}
+	client.reports[rcbReference] = subscription
+
+	return nil
+}
+
+// UninstallReportHandler stop calling the handler of the report control block, the reports already received are
+// still delivered
+func (client *IedClient) UninstallReportHandler(rcbReference string) error {
+	if err := client.acquire(); err != nil {
+		return err
+	}
+	defer client.release()
+
+	cRcbReference := C.CString(rcbReference)
+	defer C.free(unsafe.Pointer(cRcbReference))
+
+	client.reportsMutex.Lock()
+	defer client.reportsMutex.Unlock()
+
+	C.IedConnection_uninstallReportHandler(client.connection, cRcbReference)
+
+	if subscription, ok := client.reports[rcbReference]; ok {
+		subscription.stop()
+		delete(client.reports, rcbReference)
+	}
+
+	return nil
+}
+
+// stopReports stop all report subscriptions of the client, called by Close
+func (client *IedClient) stopReports() {
+	client.reportsMutex.Lock()
+	defer client.reportsMutex.Unlock()
+
+	for rcbReference, subscription := range client.reports {
+		subscription.stop()
+		delete(client.reports, rcbReference)
+	}
+}
+
+func (s *reportSubscription) received(report Report) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.stopped {
+		return
+	}
+
+	s.pending = append(s.pending, report)
+	s.cond.Signal()
+}
+
+// stop unregister the subscription, the pending reports are still delivered
+func (s *reportSubscription) stop() {
+	reportSubscriptionsMutex.Lock()
+	delete(reportSubscriptions, s.id)
+	reportSubscriptionsMutex.Unlock()
+
+	s.mutex.Lock()
+	s.stopped = true
+	s.cond.Signal()
+	s.mutex.Unlock()
+}
+
+func (s *reportSubscription) dispatch() {
+	s.mutex.Lock()
+	for {
+		for len(s.pending) == 0 && !s.stopped {
+			s.cond.Wait()
+		}
+
+		if len(s.pending) == 0 {
+			s.mutex.Unlock()
+			return
+		}
+
+		report := s.pending[0]
+		s.pending = s.pending[1:]
+		s.mutex.Unlock()
+
+		s.handler(report)
+
+		s.mutex.Lock()
+	}
+}
+
+// newReport copy the received report, the ClientReport is only valid during the report callback
+func (s *reportSubscription) newReport(clientReport C.ClientReport) Report {
+	report := Report{
+		RcbReference: cStringToGoString(C.ClientReport_getRcbReference(clientReport)),
+		RptID:        cStringToGoString(C.ClientReport_getRptId(clientReport)),
+		HasSeqNum:    bool(C.ClientReport_hasSeqNum(clientReport)),
+		HasConfRev:   bool(C.ClientReport_hasConfRev(clientReport)),
+
+		withoutTimestamps: s.client.withoutTimestamps,
+	}
+
+	if bool(C.ClientReport_hasDataSetName(clientReport)) {
+		report.DataSetName = cStringToGoString(C.ClientReport_getDataSetName(clientReport))
+	}
+	if report.HasSeqNum {
+		report.SeqNum = uint16(C.ClientReport_getSeqNum(clientReport))
+	}
+	if bool(C.ClientReport_hasSubSeqNum(clientReport)) {
+		report.SubSeqNum = uint16(C.ClientReport_getSubSeqNum(clientReport))
+		report.MoreSegmentsFollow = bool(C.ClientReport_getMoreSeqmentsFollow(clientReport))
+	}
+	if entryId := C.ClientReport_getEntryId(clientReport); entryId != nil {
+		report.EntryID = octetStringBytes(entryId)
+	}
+	if bool(C.ClientReport_hasBufOvfl(clientReport)) {
+		report.BufOvfl = bool(C.ClientReport_getBufOvfl(clientReport))
+	}
+	if report.HasConfRev {
+		report.ConfRev = uint32(C.ClientReport_getConfRev(clientReport))
+	}
+	if bool(C.ClientReport_hasTimestamp(clientReport)) {
+		report.Timestamp = time.UnixMilli(int64(C.ClientReport_getTimestamp(clientReport)))
+	}
+
+	values := C.ClientReport_getDataSetValues(clientReport)
+	if values == nil {
+		return report
+	}
+
+	size := int(C.MmsValue_getArraySize(values))
+	report.Values = make([]MmsValue, size)
+	report.Reasons = make([]ReasonForInclusion, size)
+
+	hasDataReference := bool(C.ClientReport_hasDataReference(clientReport))
+	if hasDataReference {
+		report.DataReferences = make([]string, size)
+	}
+
+	for i := 0; i < size; i++ {
+		reason := ReasonForInclusion(C.ClientReport_getReasonForInclusion(clientReport, C.int(i)))
+		report.Reasons[i] = reason
+		if reason == REASON_NOT_INCLUDED {
+			continue
+		}
+
+		report.Values[i] = s.client.toMmsValue(C.MmsValue_getElement(values, C.int(i)))
+		if hasDataReference {
+			report.DataReferences[i] = cStringToGoString(C.ClientReport_getDataReference(clientReport, C.int(i)))
+			report.Values[i] = s.client.decodeNamed(report.DataReferences[i], report.Values[i])
+		}
+	}
+
+	return report
+}
+
+// octetStringBytes copy an MMS octet string, nil for a NULL value
+func octetStringBytes(value *C.MmsValue) []byte {
+	if value == nil {
+		return nil
+	}
+
+	size := C.MmsValue_getOctetStringSize(value)
+	return C.GoBytes(unsafe.Pointer(C.MmsValue_getOctetStringBuffer(value)), C.int(size))
+}
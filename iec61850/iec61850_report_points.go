@@ -0,0 +1,74 @@
+package iec61850
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/weiheng-tech/go-libiec61850/iec61850/scl_xml"
+)
+
+// PointValue a basic value of a report with the reason its data set member was included
+type PointValue struct {
+	Value  MmsValue
+	Reason ReasonForInclusion
+}
+
+// ExplainReport map the basic values of the members included in the report to their references, e.g.
+// "IEDLD0/MMXU1.TotW.mag.f" like ExplainDataSetComponents. The components are named by the DOType, DAType and BDA
+// definitions of the SCL, a member without type definition is mapped as a whole.
+func ExplainReport(report Report, dSetScl *scl_xml.DataSetDetail) (map[string]PointValue, error) {
+	if len(dSetScl.FCDA) != len(report.Values) {
+		return nil, fmt.Errorf("failed to explain report %s, data set %s has %d members, report has %d",
+			report.RptID, dSetScl.Name, len(dSetScl.FCDA), len(report.Values))
+	}
+
+	points := make(map[string]PointValue)
+	for i, fcda := range dSetScl.FCDA {
+		if !report.Included(i) {
+			continue
+		}
+
+		ref := fcdaReference(dSetScl.IEDName, fcda)
+		reason := report.Reasons[i]
+
+		node, err := dSetScl.ResolveFCDA(fcda)
+		if err != nil {
+			points[ref] = PointValue{Value: report.Values[i], Reason: reason}
+			continue
+		}
+
+		explainByType(node, fcda.FC, report.Values[i], ref, func(path string, value MmsValue) {
+			if !report.withoutTimestamps {
+				value = decodeQuality(path, value)
+			}
+			points[path] = PointValue{Value: value, Reason: reason}
+		})
+	}
+
+	return points, nil
+}
+
+// explainByType split value into its basic components named by the SCL type node, a component which does not match
+// its type is passed as a whole
+func explainByType(node *scl_xml.TypeNode, fc string, value MmsValue, path string, leaf func(string, MmsValue)) {
+	if array, ok := value.(Array); ok && node.Count > 0 {
+		element := *node
+		element.Count = 0
+		for i, v := range array.Elements {
+			explainByType(&element, fc, v, path+"("+strconv.Itoa(i)+")", leaf)
+		}
+		return
+	}
+
+	if structure, ok := value.(Structure); ok {
+		components := node.Components(fc)
+		if len(components) == len(structure) {
+			for i, component := range components {
+				explainByType(component, fc, structure[i], joinPath(path, component.Name), leaf)
+			}
+			return
+		}
+	}
+
+	leaf(path, value)
+}
@@ -0,0 +1,169 @@
+package iec61850
+
+// #include <iec61850_client.h>
+import "C"
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"unsafe"
+)
+
+// defaultReservationTime the ResvTms in seconds written when reserving a BRCB, the IED keeps the reservation for this
+// time after the connection is lost
+const defaultReservationTime = 60
+
+// ReservedRCB a report control block reserved by the client, Close releases the reservation
+type ReservedRCB struct {
+	client *IedClient
+	// Values the attributes after the reservation
+	Values *RCBValues
+
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// ReportControlBlocks list the references of the buffered or unbuffered report control blocks of a logical node,
+// e.g. "LD0/LLN0.RP.urcbA01"
+func (client *IedClient) ReportControlBlocks(lnReference string, buffered bool) ([]string, error) {
+	if err := client.acquire(); err != nil {
+		return nil, err
+	}
+	defer client.release()
+
+	cLnReference := C.CString(lnReference)
+	defer C.free(unsafe.Pointer(cLnReference))
+
+	acsiClass, fc := C.ACSIClass(C.ACSI_CLASS_URCB), ".RP."
+	if buffered {
+		acsiClass, fc = C.ACSI_CLASS_BRCB, ".BR."
+	}
+
+	var clientError C.IedClientError
+	names := C.IedConnection_getLogicalNodeDirectory(client.connection, &clientError, cLnReference, acsiClass)
+
+	if clientError != C.IED_ERROR_OK {
+		return nil, newClientError("get logical node directory", lnReference, clientError)
+	}
+
+	defer C.LinkedList_destroy(names)
+
+	var references []string
+	for name := C.LinkedList_getNext(names); name != nil; name = C.LinkedList_getNext(name) {
+		references = append(references, lnReference+fc+C.GoString((*C.char)(name.data)))
+	}
+
+	return references, nil
+}
+
+// ReserveRCB reserve the first free buffered or unbuffered report control block of the logical node and set its
+// data set, e.g. ReserveRCB("LD0/LLN0", false, "LD0/LLN0$Events"). A report control block is free if it is not
+// enabled, not reserved (Resv, ResvTms) and has no Owner. A URCB is reserved by Resv, a BRCB by ResvTms, a BRCB
+// without ResvTms (edition 1) cannot be reserved and is skipped. If none is free the error wraps
+// ErrTemporarilyUnavailable, if the only free ones have no ResvTms it wraps ErrObjectAccessUnsupported.
+func (client *IedClient) ReserveRCB(lnReference string, buffered bool, dataSetReference string) (*ReservedRCB, error) {
+	references, err := client.ReportControlBlocks(lnReference, buffered)
+	if err != nil {
+		return nil, err
+	}
+
+	withoutResvTms := false
+	for _, reference := range references {
+		rcb, err := client.GetRCBValues(reference)
+		if err != nil {
+			if isConnectionError(err) {
+				return nil, err
+			}
+			continue
+		}
+
+		if !rcb.free() {
+			continue
+		}
+		if rcb.Buffered && !rcb.HasResvTms {
+			withoutResvTms = true
+			continue
+		}
+
+		mask := RCB_ELEMENT_DATSET
+		rcb.DatSet = dataSetReference
+		if rcb.Buffered {
+			rcb.ResvTms = defaultReservationTime
+			mask |= RCB_ELEMENT_RESV_TMS
+		} else {
+			rcb.Resv = true
+			mask |= RCB_ELEMENT_RESV
+		}
+
+		// another client may have reserved it since it was read, the IED rejects the write then
+		if err := client.SetRCBValues(rcb, mask, true); err != nil {
+			if isConnectionError(err) {
+				return nil, err
+			}
+			continue
+		}
+
+		return &ReservedRCB{client: client, Values: rcb}, nil
+	}
+
+	if withoutResvTms {
+		return nil, &ClientError{Op: "reserve report control block of", Ref: lnReference, Code: IED_ERROR_OBJECT_ACCESS_UNSUPPORTED}
+	}
+
+	return nil, &ClientError{Op: "reserve report control block of", Ref: lnReference, Code: IED_ERROR_TEMPORARILY_UNAVAILABLE}
+}
+
+// free whether no other client uses or reserved the report control block
+func (rcb *RCBValues) free() bool {
+	if rcb.RptEna || rcb.Resv || (rcb.HasResvTms && rcb.ResvTms != 0) {
+		return false
+	}
+
+	for _, b := range rcb.Owner {
+		if b != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isConnectionError errors after which no other request can succeed
+func isConnectionError(err error) bool {
+	return errors.Is(err, ErrClientClosed) || errors.Is(err, ErrNotConnected) || errors.Is(err, ErrConnectionLost)
+}
+
+// Enable install the report handler and enable the report control block
+func (r *ReservedRCB) Enable(handler func(Report)) error {
+	if err := r.client.InstallReportHandler(r.Values.Ref, r.Values.RptID, handler); err != nil {
+		return err
+	}
+
+	r.Values.RptEna = true
+	return r.client.SetRCBValues(r.Values, RCB_ELEMENT_RPT_ENA, true)
+}
+
+// Close disable the report control block, uninstall the report handler and release the reservation. Calling Close
+// more than once is safe.
+func (r *ReservedRCB) Close() error {
+	r.closeOnce.Do(func() {
+		mask := RCB_ELEMENT_RPT_ENA
+		r.Values.RptEna = false
+		if r.Values.Buffered {
+			r.Values.ResvTms = 0
+			mask |= RCB_ELEMENT_RESV_TMS
+		} else {
+			r.Values.Resv = false
+			mask |= RCB_ELEMENT_RESV
+		}
+
+		if err := r.client.SetRCBValues(r.Values, mask, true); err != nil {
+			r.closeErr = fmt.Errorf("failed to release report control block %s, %w", r.Values.Ref, err)
+		}
+		if err := r.client.UninstallReportHandler(r.Values.Ref); err != nil && r.closeErr == nil {
+			r.closeErr = err
+		}
+	})
+
+	return r.closeErr
+}
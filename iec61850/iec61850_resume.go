@@ -0,0 +1,264 @@
+package iec61850
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+)
+
+// DefaultEntryIDDir the directory of the FileEntryIDStore used by ResumeBufferedReport without ResumeStore, relative
+// to the working directory. The EntryIDs of every IED are stored in a sub directory named by its escaped
+// "hostname:port", so IEDs built from the same ICD with the same report control block references do not share their
+// EntryIDs.
+var DefaultEntryIDDir = "entryid"
+
+// EntryIDStore persists the EntryID of the last report received from a buffered report control block
+type EntryIDStore interface {
+	// Load the stored EntryID, nil without error if none is stored
+	Load(rcbReference string) ([]byte, error)
+	Store(rcbReference string, entryID []byte) error
+}
+
+// FileEntryIDStore stores every EntryID in a file of Dir named by the escaped report control block reference
+type FileEntryIDStore struct {
+	Dir string
+}
+
+func NewFileEntryIDStore(dir string) *FileEntryIDStore {
+	return &FileEntryIDStore{Dir: dir}
+}
+
+func (s *FileEntryIDStore) path(rcbReference string) string {
+	return filepath.Join(s.Dir, url.PathEscape(rcbReference))
+}
+
+func (s *FileEntryIDStore) Load(rcbReference string) ([]byte, error) {
+	entryID, err := os.ReadFile(s.path(rcbReference))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	return entryID, err
+}
+
+// Store write the EntryID to a temporary file, which is synced before it replaces the stored EntryID, then the
+// directory is synced, so neither a crash nor a power loss leaves a partly written EntryID
+func (s *FileEntryIDStore) Store(rcbReference string, entryID []byte) error {
+	if err := os.MkdirAll(s.Dir, 0o755); err != nil {
+		return err
+	}
+
+	path := s.path(rcbReference)
+	if err := writeFileSync(path+".tmp", entryID); err != nil {
+		return err
+	}
+	if err := os.Rename(path+".tmp", path); err != nil {
+		return err
+	}
+	return syncDir(s.Dir)
+}
+
+func writeFileSync(path string, data []byte) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// syncDir persist the entries of dir, e.g. a renamed file. Windows can not sync a directory, a rename there is
+// persisted with the file.
+func syncDir(dir string) error {
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+
+	file, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return file.Sync()
+}
+
+// GapCause why reports of a buffered report control block may have been lost
+type GapCause int
+
+const (
+	// GAP_CAUSE_BUFFER_OVERFLOW the IED reported an overflow of its buffer, the oldest entries were overwritten
+	GAP_CAUSE_BUFFER_OVERFLOW GapCause = iota
+	// GAP_CAUSE_ENTRY_NOT_FOUND the IED rejected the stored EntryID, the buffer was purged, overwritten or the IED
+	// restarted, the reports continue with the oldest buffered entry
+	GAP_CAUSE_ENTRY_NOT_FOUND
+	// GAP_CAUSE_STORE_FAILED the EntryID could not be stored, the next resume may repeat reports
+	GAP_CAUSE_STORE_FAILED
+)
+
+func (c GapCause) String() string {
+	switch c {
+	case GAP_CAUSE_BUFFER_OVERFLOW:
+		return "GAP_CAUSE_BUFFER_OVERFLOW"
+	case GAP_CAUSE_ENTRY_NOT_FOUND:
+		return "GAP_CAUSE_ENTRY_NOT_FOUND"
+	case GAP_CAUSE_STORE_FAILED:
+		return "GAP_CAUSE_STORE_FAILED"
+	}
+
+	return "GAP_CAUSE_UNDEFINED"
+}
+
+// ReportGap reports of a buffered report control block after EntryID may have been lost
+type ReportGap struct {
+	RcbReference string
+	Cause        GapCause
+	// EntryID the last EntryID received or stored before the gap, nil if none
+	EntryID []byte
+	Err     error
+}
+
+type resumeOptions struct {
+	store EntryIDStore
+	onGap func(ReportGap)
+	gi    bool
+}
+
+type ResumeOption func(*resumeOptions)
+
+// ResumeStore the store of the EntryIDs, a FileEntryIDStore in the directory of the IED in DefaultEntryIDDir if not
+// set. Without ResumeStore the client has to be connected before ResumeBufferedReport.
+func ResumeStore(store EntryIDStore) ResumeOption {
+	return func(o *resumeOptions) {
+		o.store = store
+	}
+}
+
+// OnReportGap call handler when reports may have been lost. GAP_CAUSE_ENTRY_NOT_FOUND is reported by
+// ResumeBufferedReport itself, the other causes from the goroutine of the report handler.
+func OnReportGap(handler func(ReportGap)) ResumeOption {
+	return func(o *resumeOptions) {
+		o.onGap = handler
+	}
+}
+
+// ResumeGI request a general interrogation after the buffered reports
+func ResumeGI(gi bool) ResumeOption {
+	return func(o *resumeOptions) {
+		o.gi = gi
+	}
+}
+
+// ResumeBufferedReport enable the buffered report control block and continue with the report after the stored
+// EntryID. The EntryID of every report is stored after handler returned, so a report is delivered again after a
+// restart if handler did not complete. Gaps in the reports are passed to the OnReportGap handler.
+func (client *IedClient) ResumeBufferedReport(rcbReference string, handler func(Report), options ...ResumeOption) error {
+	resume := &resumeOptions{}
+	for _, option := range options {
+		if option != nil {
+			option(resume)
+		}
+	}
+	if resume.store == nil {
+		// the directory is named after the IED, which is only known once the client connected
+		peer := client.peerAddress()
+		if peer == "" {
+			return fmt.Errorf("failed to resume report %s, not connected and no ResumeStore given, %w", rcbReference, ErrNotConnected)
+		}
+		resume.store = NewFileEntryIDStore(filepath.Join(DefaultEntryIDDir, url.QueryEscape(peer)))
+	}
+	if resume.onGap == nil {
+		resume.onGap = func(ReportGap) {}
+	}
+
+	rcb, err := client.GetRCBValues(rcbReference)
+	if err != nil {
+		return err
+	}
+	if !rcb.Buffered {
+		return fmt.Errorf("failed to resume report %s, not a buffered report control block", rcbReference)
+	}
+
+	// the EntryID can only be written while the report control block is disabled
+	if rcb.RptEna {
+		rcb.RptEna = false
+		if err := client.SetRCBValues(rcb, RCB_ELEMENT_RPT_ENA, true); err != nil {
+			return err
+		}
+	}
+
+	entryID, err := resume.store.Load(rcbReference)
+	if err != nil {
+		return fmt.Errorf("failed to resume report %s, %w", rcbReference, err)
+	}
+
+	var mutex sync.Mutex
+	lastEntryID := entryID
+
+	err = client.InstallReportHandler(rcbReference, rcb.RptID, func(report Report) {
+		mutex.Lock()
+		last := lastEntryID
+		mutex.Unlock()
+
+		if report.BufOvfl {
+			resume.onGap(ReportGap{RcbReference: rcbReference, Cause: GAP_CAUSE_BUFFER_OVERFLOW, EntryID: last})
+		}
+
+		handler(report)
+
+		if report.EntryID == nil {
+			return
+		}
+
+		mutex.Lock()
+		lastEntryID = report.EntryID
+		mutex.Unlock()
+
+		if err := resume.store.Store(rcbReference, report.EntryID); err != nil {
+			resume.onGap(ReportGap{RcbReference: rcbReference, Cause: GAP_CAUSE_STORE_FAILED, EntryID: report.EntryID, Err: err})
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	if entryID != nil {
+		rcb.EntryID = entryID
+		if err := client.SetRCBValues(rcb, RCB_ELEMENT_ENTRY_ID, true); err != nil {
+			if isConnectionError(err) {
+				return err
+			}
+			resume.onGap(ReportGap{RcbReference: rcbReference, Cause: GAP_CAUSE_ENTRY_NOT_FOUND, EntryID: entryID, Err: err})
+		}
+	}
+
+	// without EntryID and BufOvfl in the reports neither resume nor gap detection works
+	mask := RCB_ELEMENT_RPT_ENA
+	if required := RPT_OPT_ENTRY_ID | RPT_OPT_BUFFER_OVERFLOW; rcb.OptFlds&required != required {
+		rcb.OptFlds |= required
+		mask |= RCB_ELEMENT_OPT_FLDS
+	}
+
+	rcb.RptEna = true
+	if err := client.SetRCBValues(rcb, mask, true); err != nil {
+		return err
+	}
+
+	if resume.gi {
+		rcb.GI = true
+		return client.SetRCBValues(rcb, RCB_ELEMENT_GI, true)
+	}
+
+	return nil
+}
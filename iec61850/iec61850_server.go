@@ -6,7 +6,9 @@ package iec61850
 import "C"
 
 type IedServer struct {
-	server C.IedServer
+	server           C.IedServer
+	tlsConfiguration C.TLSConfiguration
+	callbacks        *serverCallbacks
 }
 
 // NewIedServer creates a new instance of the IedServer using the provided model.
@@ -30,11 +32,20 @@ func (is *IedServer) IsRunning() bool {
 // Stop terminates the IedServer.
 func (is *IedServer) Stop() {
 	C.IedServer_stop(is.server)
+	if is.callbacks != nil {
+		is.callbacks.stopped()
+	}
 }
 
 // Destroy frees all resources associated with the IedServer.
 func (is *IedServer) Destroy() {
 	C.IedServer_destroy(is.server)
+	if is.tlsConfiguration != nil {
+		destroyTLSConfiguration(is.tlsConfiguration)
+	}
+	if is.callbacks != nil {
+		is.callbacks.unregister()
+	}
 }
 
 // LockDataModel locks the data model of the IedServer.
@@ -62,6 +73,16 @@ func (is *IedServer) UpdateInt32AttributeValue(attr *DataAttribute, value int32)
 	C.IedServer_updateInt32AttributeValue(is.server, attr.attribute, C.int32_t(value))
 }
 
+// UpdateBooleanAttributeValue updates a DataAttribute with a boolean value.
+func (is *IedServer) UpdateBooleanAttributeValue(attr *DataAttribute, value bool) {
+	C.IedServer_updateBooleanAttributeValue(is.server, attr.attribute, C.bool(value))
+}
+
+// UpdateDbposValue updates the stVal of a DPS or DPC.
+func (is *IedServer) UpdateDbposValue(attr *DataAttribute, value Dbpos) {
+	C.IedServer_updateDbposValue(is.server, attr.attribute, C.Dbpos(value))
+}
+
 // UpdateVisibleStringAttributeValue updates a DataAttribute with a visible string value.
 func (is *IedServer) UpdateVisibleStringAttributeValue(attr *DataAttribute, value string) {
 	C.IedServer_updateVisibleStringAttributeValue(is.server, attr.attribute, C.CString(value))
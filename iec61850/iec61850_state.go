@@ -0,0 +1,222 @@
+package iec61850
+
+/*
+#include <stdint.h>
+#include <iec61850_client.h>
+
+extern void goStateChangedHandler(void* parameter, IedConnection connection, IedConnectionState newState);
+
+static void installStateChangedHandler(IedConnection self, uintptr_t id)
+{
+	IedConnection_installStateChangedHandler(self, goStateChangedHandler, (void*) id);
+}
+*/
+import "C"
+import "sync"
+
+// StateChangeCause why the state of the connection changed, libiec61850 only reports the new state so the cause is
+// derived from the previous state and whether the client closed the connection itself
+type StateChangeCause int
+
+const (
+	// STATE_CAUSE_CONNECT a connection attempt of the client
+	STATE_CAUSE_CONNECT StateChangeCause = iota
+	// STATE_CAUSE_CONNECT_FAILED the connection attempt failed, timed out or was rejected
+	STATE_CAUSE_CONNECT_FAILED
+	// STATE_CAUSE_CLOSE the client closed the connection
+	STATE_CAUSE_CLOSE
+	// STATE_CAUSE_CONNECTION_LOST the server closed the connection or the connection was lost
+	STATE_CAUSE_CONNECTION_LOST
+)
+
+// stateChangeBufferSize the buffer of every channel returned by StateChanges
+const stateChangeBufferSize = 16
+
+func (c StateChangeCause) String() string {
+	switch c {
+	case STATE_CAUSE_CONNECT:
+		return "STATE_CAUSE_CONNECT"
+	case STATE_CAUSE_CONNECT_FAILED:
+		return "STATE_CAUSE_CONNECT_FAILED"
+	case STATE_CAUSE_CLOSE:
+		return "STATE_CAUSE_CLOSE"
+	case STATE_CAUSE_CONNECTION_LOST:
+		return "STATE_CAUSE_CONNECTION_LOST"
+	}
+
+	return "STATE_CAUSE_UNDEFINED"
+}
+
+func (s ClientState) String() string {
+	switch s {
+	case IED_STATE_CLOSED:
+		return "IED_STATE_CLOSED"
+	case IED_STATE_CONNECTING:
+		return "IED_STATE_CONNECTING"
+	case IED_STATE_CONNECTED:
+		return "IED_STATE_CONNECTED"
+	case IED_STATE_CLOSING:
+		return "IED_STATE_CLOSING"
+	}
+
+	return "IED_STATE_UNDEFINED"
+}
+
+// StateChange a transition of the connection state
+type StateChange struct {
+	Previous ClientState
+	State    ClientState
+	Cause    StateChangeCause
+}
+
+// stateNotifier queues the state changes reported on the connection thread of libiec61850 and delivers them in
+// order from its own goroutine, so handlers may call the client without blocking the connection
+type stateNotifier struct {
+	id uintptr
+
+	mutex          sync.Mutex
+	cond           *sync.Cond
+	state          ClientState
+	closeRequested bool
+	stopped        bool
+	pending        []StateChange
+	handlers       []func(StateChange)
+	subscribers    []chan StateChange
+}
+
+var (
+	stateNotifiersMutex sync.Mutex
+	stateNotifiers      = make(map[uintptr]*stateNotifier)
+	stateNotifierId     uintptr
+)
+
+// newStateNotifier register a notifier and install it as state changed handler of the connection
+func newStateNotifier(connection C.IedConnection) *stateNotifier {
+	notifier := &stateNotifier{state: IED_STATE_CLOSED}
+	notifier.cond = sync.NewCond(&notifier.mutex)
+
+	stateNotifiersMutex.Lock()
+	stateNotifierId++
+	notifier.id = stateNotifierId
+	stateNotifiers[notifier.id] = notifier
+	stateNotifiersMutex.Unlock()
+
+	C.installStateChangedHandler(connection, C.uintptr_t(notifier.id))
+
+	go notifier.dispatch()
+
+	return notifier
+}
+
+func lookupStateNotifier(id uintptr) *stateNotifier {
+	stateNotifiersMutex.Lock()
+	defer stateNotifiersMutex.Unlock()
+
+	return stateNotifiers[id]
+}
+
+// OnStateChange call handler for every following state change of the connection. The handlers are called one after
+// another from a single goroutine, a handler blocking for long delays the later state changes.
+func (client *IedClient) OnStateChange(handler func(StateChange)) {
+	client.notifier.mutex.Lock()
+	defer client.notifier.mutex.Unlock()
+
+	client.notifier.handlers = append(client.notifier.handlers, handler)
+}
+
+// StateChanges a channel receiving every following state change of the connection, it is closed by Close. The
+// channel has to be drained, otherwise it delays the delivery to the other handlers.
+func (client *IedClient) StateChanges() <-chan StateChange {
+	changes := make(chan StateChange, stateChangeBufferSize)
+
+	client.notifier.mutex.Lock()
+	defer client.notifier.mutex.Unlock()
+
+	if client.notifier.stopped {
+		close(changes)
+		return changes
+	}
+
+	client.notifier.subscribers = append(client.notifier.subscribers, changes)
+	return changes
+}
+
+// requestClose mark the following transition to closed as caused by the client
+func (n *stateNotifier) requestClose(requested bool) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	n.closeRequested = requested
+}
+
+func (n *stateNotifier) changed(state ClientState) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	if n.stopped || state == n.state {
+		return
+	}
+
+	change := StateChange{Previous: n.state, State: state}
+	switch {
+	case state == IED_STATE_CONNECTING || state == IED_STATE_CONNECTED:
+		change.Cause = STATE_CAUSE_CONNECT
+	case n.closeRequested:
+		change.Cause = STATE_CAUSE_CLOSE
+	case n.state == IED_STATE_CONNECTING:
+		change.Cause = STATE_CAUSE_CONNECT_FAILED
+	default:
+		change.Cause = STATE_CAUSE_CONNECTION_LOST
+	}
+
+	n.state = state
+	n.pending = append(n.pending, change)
+	n.cond.Signal()
+}
+
+// stop unregister the notifier, the pending state changes are still delivered before the subscribers are closed
+func (n *stateNotifier) stop() {
+	stateNotifiersMutex.Lock()
+	delete(stateNotifiers, n.id)
+	stateNotifiersMutex.Unlock()
+
+	n.mutex.Lock()
+	n.stopped = true
+	n.cond.Signal()
+	n.mutex.Unlock()
+}
+
+func (n *stateNotifier) dispatch() {
+	n.mutex.Lock()
+	for {
+		for len(n.pending) == 0 && !n.stopped {
+			n.cond.Wait()
+		}
+
+		if len(n.pending) == 0 {
+			subscribers := n.subscribers
+			n.subscribers = nil
+			n.mutex.Unlock()
+
+			for _, subscriber := range subscribers {
+				close(subscriber)
+			}
+			return
+		}
+
+		change := n.pending[0]
+		n.pending = n.pending[1:]
+		handlers := n.handlers
+		subscribers := n.subscribers
+		n.mutex.Unlock()
+
+		for _, handler := range handlers {
+			handler(change)
+		}
+		for _, subscriber := range subscribers {
+			subscriber <- change
+		}
+
+		n.mutex.Lock()
+	}
+}
@@ -0,0 +1,366 @@
+package iec61850
+
+/*
+#include <iec61850_client.h>
+#include <mms_value_internal.h>
+
+static int MmsValue_getFloatFormatWidth(const MmsValue* self)
+{
+	return self->value.floatingPoint.formatWidth;
+}
+
+static int MmsValue_getBinaryTimeSize(const MmsValue* self)
+{
+	return self->value.binaryTime.size;
+}
+*/
+import "C"
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unsafe"
+)
+
+// MmsValue the Go representation of a libiec61850 MmsValue, lossless for every type libiec61850 stores the content
+// of, i.e. all types except GeneralizedTime, BCD and ObjectID
+// Refer to https://support.mz-automation.de/doc/libiec61850/c/latest/group__MMS__VALUE.html
+type MmsValue interface {
+	Type() MMSType
+}
+
+type Boolean bool
+
+type Integer int64
+
+type Unsigned uint32
+
+// Float32 MMS_FLOAT with a format width of 32 bit
+type Float32 float32
+
+// Float64 MMS_FLOAT with a format width of 64 bit
+type Float64 float64
+
+// BitString the bits are stored MSB first, bit 0 is the highest bit of Bits[0]
+type BitString struct {
+	Size int
+	Bits []byte
+}
+
+type OctetString []byte
+
+type VisibleString string
+
+type String string
+
+// UtcTime the raw 8 bytes of an IEC 61850-8-1 UtcTime: 4 bytes seconds, 3 bytes fraction of second and 1 byte TimeQuality
+type UtcTime [8]byte
+
+// BinaryTime the milliseconds since epoch, TimeOfDay is set when the value has no date part (4 bytes encoding)
+type BinaryTime struct {
+	Ms        uint64
+	TimeOfDay bool
+}
+
+// GeneralizedTime, BCD and ObjectID have no storage in a libiec61850 MmsValue, the value is the text printed by
+// MmsValue_printToBuffer. They can not be written, newMmsValue returns a MmsTypeError for them.
+
+type GeneralizedTime string
+
+type BCD string
+
+type ObjectID string
+
+type DataAccessError int
+
+type Structure []MmsValue
+
+// Array the elements of an MMS array all have the same type, ElementType is the type of the first element which is
+// not nil, MMS_NIL if there is none
+type Array struct {
+	ElementType MMSType
+	Elements    []MmsValue
+}
+
+func (Boolean) Type() MMSType         { return MMS_BOOLEAN }
+func (Integer) Type() MMSType         { return MMS_INTEGER }
+func (Unsigned) Type() MMSType        { return MMS_UNSIGNED }
+func (Float32) Type() MMSType         { return MMS_FLOAT }
+func (Float64) Type() MMSType         { return MMS_FLOAT }
+func (BitString) Type() MMSType       { return MMS_BIT_STRING }
+func (OctetString) Type() MMSType     { return MMS_OCTET_STRING }
+func (VisibleString) Type() MMSType   { return MMS_VISIBLE_STRING }
+func (String) Type() MMSType          { return MMS_STRING }
+func (UtcTime) Type() MMSType         { return MMS_UTC_TIME }
+func (BinaryTime) Type() MMSType      { return MMS_BINARY_TIME }
+func (GeneralizedTime) Type() MMSType { return MMS_GENERALIZED_TIME }
+func (BCD) Type() MMSType             { return MMS_BCD }
+func (ObjectID) Type() MMSType        { return MMS_OBJ_ID }
+func (DataAccessError) Type() MMSType { return MMS_DATA_ACCESS_ERROR }
+func (Structure) Type() MMSType       { return MMS_STRUCTURE }
+func (Array) Type() MMSType           { return MMS_ARRAY }
+
+// NewBitString create a bit string of size bits from an integer, bit 0 is the lowest bit of value
+func NewBitString(size int, value uint32) BitString {
+	bs := BitString{Size: size, Bits: make([]byte, (size+7)/8)}
+	for i := 0; i < size && i < 32; i++ {
+		bs.SetBit(i, value&(1<<i) != 0)
+	}
+	return bs
+}
+
+func (bs BitString) Bit(pos int) bool {
+	if pos < 0 || pos >= bs.Size {
+		return false
+	}
+	return bs.Bits[pos/8]&(0x80>>(pos%8)) != 0
+}
+
+func (bs BitString) SetBit(pos int, value bool) {
+	if pos < 0 || pos >= bs.Size {
+		return
+	}
+	if value {
+		bs.Bits[pos/8] |= 0x80 >> (pos % 8)
+	} else {
+		bs.Bits[pos/8] &^= 0x80 >> (pos % 8)
+	}
+}
+
+// Uint32 same as MmsValue_getBitStringAsInteger, bit 0 is the lowest bit of the result
+func (bs BitString) Uint32() uint32 {
+	var value uint32
+	for i := 0; i < bs.Size && i < 32; i++ {
+		if bs.Bit(i) {
+			value |= 1 << i
+		}
+	}
+	return value
+}
+
+func (t UtcTime) Seconds() uint32 {
+	return uint32(t[0])<<24 | uint32(t[1])<<16 | uint32(t[2])<<8 | uint32(t[3])
+}
+
+// FractionOfSecond the 24 bit binary fraction of a second
+func (t UtcTime) FractionOfSecond() uint32 {
+	return uint32(t[4])<<16 | uint32(t[5])<<8 | uint32(t[6])
+}
+
+func (t UtcTime) TimeQuality() uint8 {
+	return t[7]
+}
+
+func (t UtcTime) Time() time.Time {
+	return t.Timestamp().Time()
+}
+
+func (t BinaryTime) Time() time.Time {
+	return time.UnixMilli(int64(t.Ms))
+}
+
+// decodeNamed decode the value of the component with the reference or path ref by its name, a q (13 or 14 bit string)
+// becomes a Quality unless WithoutTimestamps is set
+func (client *IedClient) decodeNamed(ref string, value MmsValue) MmsValue {
+	if client.withoutTimestamps {
+		return value
+	}
+	return decodeQuality(ref, value)
+}
+
+// decodeQuality the Quality of the bit string value if the last name of ref is "q"
+func decodeQuality(ref string, value MmsValue) MmsValue {
+	bs, ok := value.(BitString)
+	if !ok || lastName(ref) != "q" {
+		return value
+	}
+	if q, ok := qualityFromBitString(bs); ok {
+		return q
+	}
+	return value
+}
+
+// lastName the last name of an object reference, a path or an MMS item id, e.g. "q" of "LD0/MMXU1.TotW.q"
+func lastName(ref string) string {
+	return ref[strings.LastIndexAny(ref, "./$")+1:]
+}
+
+// toMmsValue convert a libiec61850 MmsValue to the Go value model, the C value is not modified. Bit strings are not
+// decoded to Quality, since a q can only be told apart by its name.
+func (client *IedClient) toMmsValue(value *C.MmsValue) MmsValue {
+	if value == nil {
+		return nil
+	}
+
+	valueType := MMSType(C.MmsValue_getType(value))
+
+	switch valueType {
+	case MMS_BOOLEAN:
+		return Boolean(C.MmsValue_getBoolean(value))
+	case MMS_INTEGER:
+		return Integer(C.MmsValue_toInt64(value))
+	case MMS_UNSIGNED:
+		return Unsigned(C.MmsValue_toUint32(value))
+	case MMS_FLOAT:
+		if C.MmsValue_getFloatFormatWidth(value) == 64 {
+			return Float64(C.MmsValue_toDouble(value))
+		}
+		return Float32(C.MmsValue_toFloat(value))
+	case MMS_BIT_STRING:
+		size := int(C.MmsValue_getBitStringSize(value))
+		bs := BitString{Size: size, Bits: make([]byte, (size+7)/8)}
+		for i := 0; i < size; i++ {
+			bs.SetBit(i, bool(C.MmsValue_getBitStringBit(value, C.int(i))))
+		}
+		// a q is decoded by its name, see decodeNamed
+		return bs
+	case MMS_OCTET_STRING:
+		size := C.MmsValue_getOctetStringSize(value)
+		return OctetString(C.GoBytes(unsafe.Pointer(C.MmsValue_getOctetStringBuffer(value)), C.int(size)))
+	case MMS_VISIBLE_STRING:
+		return VisibleString(cStringToGoString(C.MmsValue_toString(value)))
+	case MMS_STRING:
+		return String(cStringToGoString(C.MmsValue_toString(value)))
+	case MMS_UTC_TIME:
+		var t UtcTime
+		copy(t[:], C.GoBytes(unsafe.Pointer(C.MmsValue_getUtcTimeBuffer(value)), 8))
+		if !client.withoutTimestamps {
+			return t.Timestamp()
+		}
+		return t
+	case MMS_BINARY_TIME:
+		return BinaryTime{
+			Ms:        uint64(C.MmsValue_getBinaryTimeAsUtcMs(value)),
+			TimeOfDay: C.MmsValue_getBinaryTimeSize(value) == 4,
+		}
+	case MMS_GENERALIZED_TIME:
+		return GeneralizedTime(mmsValueToText(value))
+	case MMS_BCD:
+		return BCD(mmsValueToText(value))
+	case MMS_OBJ_ID:
+		return ObjectID(mmsValueToText(value))
+	case MMS_DATA_ACCESS_ERROR:
+		return DataAccessError(C.MmsValue_getDataAccessError(value))
+	case MMS_STRUCTURE:
+		return client.digIntoStructure(value)
+	case MMS_ARRAY:
+		return client.digIntoArray(value)
+	}
+
+	return nil
+}
+
+func (client *IedClient) digIntoStructure(mms *C.MmsValue) Structure {
+	size := int(C.MmsValue_getArraySize(mms))
+	goValues := make(Structure, size)
+	for i := 0; i < size; i++ {
+		goValues[i] = client.toMmsValue(C.MmsValue_getElement(mms, C.int(i)))
+	}
+	return goValues
+}
+
+// digIntoArray arrays may contain structures or other arrays (e.g. HMV/HWYE harmonics, CSD curves)
+func (client *IedClient) digIntoArray(mms *C.MmsValue) Array {
+	size := int(C.MmsValue_getArraySize(mms))
+	goValues := Array{
+		ElementType: MMS_NIL,
+		Elements:    make([]MmsValue, size),
+	}
+	for i := 0; i < size; i++ {
+		element := C.MmsValue_getElement(mms, C.int(i))
+		if element == nil {
+			continue
+		}
+		if goValues.ElementType == MMS_NIL {
+			goValues.ElementType = MMSType(C.MmsValue_getType(element))
+		}
+		goValues.Elements[i] = client.toMmsValue(element)
+	}
+	return goValues
+}
+
+// mmsValueToText print the value, the buffer grows until the text fits
+func mmsValueToText(value *C.MmsValue) string {
+	for size := 128; ; size *= 2 {
+		buffer := make([]C.char, size)
+		text := C.GoString(C.MmsValue_printToBuffer(value, &buffer[0], C.int(size)))
+		if len(text) < size-1 || size >= 1<<16 {
+			return text
+		}
+	}
+}
+
+// newMmsValue convert a Go value to a new libiec61850 MmsValue, the caller has to delete it with MmsValue_delete
+func newMmsValue(value MmsValue) (*C.MmsValue, error) {
+	switch v := value.(type) {
+	case Boolean:
+		return C.MmsValue_newBoolean(C.bool(v)), nil
+	case Integer:
+		return C.MmsValue_newIntegerFromInt64(C.int64_t(v)), nil
+	case Unsigned:
+		return C.MmsValue_newUnsignedFromUint32(C.uint32_t(v)), nil
+	case Float32:
+		return C.MmsValue_newFloat(C.float(v)), nil
+	case Float64:
+		return C.MmsValue_newDouble(C.double(v)), nil
+	case BitString:
+		mmsValue := C.MmsValue_newBitString(C.int(v.Size))
+		for i := 0; i < v.Size; i++ {
+			C.MmsValue_setBitStringBit(mmsValue, C.int(i), C.bool(v.Bit(i)))
+		}
+		return mmsValue, nil
+	case OctetString:
+		mmsValue := C.MmsValue_newOctetString(C.int(len(v)), C.int(len(v)))
+		if len(v) > 0 {
+			C.MmsValue_setOctetString(mmsValue, (*C.uint8_t)(unsafe.Pointer(&v[0])), C.int(len(v)))
+		}
+		return mmsValue, nil
+	case VisibleString:
+		cValue := C.CString(string(v))
+		defer C.free(unsafe.Pointer(cValue))
+		return C.MmsValue_newVisibleString(cValue), nil
+	case String:
+		cValue := C.CString(string(v))
+		defer C.free(unsafe.Pointer(cValue))
+		return C.MmsValue_newMmsString(cValue), nil
+	case Quality:
+		return newMmsValue(v.BitString())
+	case Timestamp:
+		return newMmsValue(v.UtcTime())
+	case UtcTime:
+		mmsValue := C.MmsValue_newUtcTime(0)
+		C.MmsValue_setUtcTimeByBuffer(mmsValue, (*C.uint8_t)(unsafe.Pointer(&v[0])))
+		return mmsValue, nil
+	case BinaryTime:
+		mmsValue := C.MmsValue_newBinaryTime(C.bool(v.TimeOfDay))
+		C.MmsValue_setBinaryTime(mmsValue, C.uint64_t(v.Ms))
+		return mmsValue, nil
+	case DataAccessError:
+		return C.MmsValue_newDataAccessError(C.MmsDataAccessError(v)), nil
+	case Structure:
+		return newMmsValueList(C.MmsValue_createEmptyStructure(C.int(len(v))), v)
+	case Array:
+		for _, element := range v.Elements {
+			if element != nil && element.Type() != v.ElementType {
+				return nil, fmt.Errorf("array element type %d mismatch element type %d", element.Type(), v.ElementType)
+			}
+		}
+		return newMmsValueList(C.MmsValue_createEmptyArray(C.int(len(v.Elements))), v.Elements)
+	case nil:
+		return nil, fmt.Errorf("nil mms value")
+	}
+
+	return nil, &MmsTypeError{Type: value.Type()}
+}
+
+func newMmsValueList(mmsValue *C.MmsValue, elements []MmsValue) (*C.MmsValue, error) {
+	for i, element := range elements {
+		elementValue, err := newMmsValue(element)
+		if err != nil {
+			C.MmsValue_delete(mmsValue)
+			return nil, err
+		}
+		C.MmsValue_setElement(mmsValue, C.int(i), elementValue)
+	}
+	return mmsValue, nil
+}
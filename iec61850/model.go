@@ -48,6 +48,13 @@ type DataObject struct {
 // VSS: Visible String Setting
 // SAV: Sampled Value
 // APC: Analogue Process Control
+// SPC: Controllable Single Point
+// SPS: Single Point Status
+// DPS: Double Point Status
+// INS: Integer Status
+// MV: Measured Value
+// CMV: Complex Measured Value
+// WYE: Phase to ground related measured values of a three-phase system
 
 func (n *LogicalNode) CreateDataObjectCDC_ENS(name string) *DataObject {
 	return &DataObject{
@@ -73,10 +80,112 @@ func (n *LogicalNode) CreateDataObjectCDC_APC(name string, ctlModel int) *DataOb
 	}
 }
 
+func (n *LogicalNode) CreateDataObjectCDC_SPC(name string, ctlModel int) *DataObject {
+	return &DataObject{
+		object: C.CDC_SPC_create(C.CString(name), (*C.ModelNode)(n.node), 0, C.uint32_t(ctlModel)),
+	}
+}
+
+func (n *LogicalNode) CreateDataObjectCDC_SPS(name string) *DataObject {
+	return n.createDataObjectCDC(name, func(cName *C.char) *C.DataObject {
+		return C.CDC_SPS_create(cName, (*C.ModelNode)(n.node), 0)
+	})
+}
+
+func (n *LogicalNode) CreateDataObjectCDC_DPS(name string) *DataObject {
+	return n.createDataObjectCDC(name, func(cName *C.char) *C.DataObject {
+		return C.CDC_DPS_create(cName, (*C.ModelNode)(n.node), 0)
+	})
+}
+
+func (n *LogicalNode) CreateDataObjectCDC_INS(name string) *DataObject {
+	return n.createDataObjectCDC(name, func(cName *C.char) *C.DataObject {
+		return C.CDC_INS_create(cName, (*C.ModelNode)(n.node), 0)
+	})
+}
+
+func (n *LogicalNode) CreateDataObjectCDC_MV(name string, isInteger bool) *DataObject {
+	return n.createDataObjectCDC(name, func(cName *C.char) *C.DataObject {
+		return C.CDC_MV_create(cName, (*C.ModelNode)(n.node), 0, C.bool(isInteger))
+	})
+}
+
+func (n *LogicalNode) CreateDataObjectCDC_CMV(name string) *DataObject {
+	return n.createDataObjectCDC(name, func(cName *C.char) *C.DataObject {
+		return C.CDC_CMV_create(cName, (*C.ModelNode)(n.node), 0)
+	})
+}
+
+// CreateDataObjectCDC_WYE creates a WYE with all phases (phsA, phsB, phsC, neut)
+func (n *LogicalNode) CreateDataObjectCDC_WYE(name string) *DataObject {
+	return n.createDataObjectCDC(name, func(cName *C.char) *C.DataObject {
+		return C.CDC_WYE_create(cName, (*C.ModelNode)(n.node), C.CDC_OPTION_PHASES_ALL)
+	})
+}
+
+func (n *LogicalNode) createDataObjectCDC(name string, create func(cName *C.char) *C.DataObject) *DataObject {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+
+	return &DataObject{object: create(cName)}
+}
+
+// CreateDataObject creates a DataObject without CDC under this LogicalNode, add its attributes by CreateDataAttribute
+func (n *LogicalNode) CreateDataObject(name string) *DataObject {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+
+	return &DataObject{
+		object: C.DataObject_create(cName, (*C.ModelNode)(n.node), 0),
+	}
+}
+
+// DataAttributeType the type of a DataAttribute created by CreateDataAttribute
+type DataAttributeType int
+
+const (
+	IEC61850_BOOLEAN            DataAttributeType = C.IEC61850_BOOLEAN
+	IEC61850_INT32              DataAttributeType = C.IEC61850_INT32
+	IEC61850_INT64              DataAttributeType = C.IEC61850_INT64
+	IEC61850_INT32U             DataAttributeType = C.IEC61850_INT32U
+	IEC61850_FLOAT32            DataAttributeType = C.IEC61850_FLOAT32
+	IEC61850_FLOAT64            DataAttributeType = C.IEC61850_FLOAT64
+	IEC61850_ENUMERATED         DataAttributeType = C.IEC61850_ENUMERATED
+	IEC61850_VISIBLE_STRING_255 DataAttributeType = C.IEC61850_VISIBLE_STRING_255
+	IEC61850_TIMESTAMP          DataAttributeType = C.IEC61850_TIMESTAMP
+	IEC61850_QUALITY            DataAttributeType = C.IEC61850_QUALITY
+	// IEC61850_CONSTRUCTED an attribute with sub attributes
+	IEC61850_CONSTRUCTED DataAttributeType = C.IEC61850_CONSTRUCTED
+)
+
 type DataAttribute struct {
 	attribute *C.DataAttribute
 }
 
+// CreateDataAttribute creates a DataAttribute under this DataObject. With arrayElements > 0 the attribute is an array,
+// the sub attributes of an IEC61850_CONSTRUCTED array are the members of every element.
+func (do *DataObject) CreateDataAttribute(name string, attributeType DataAttributeType, fc FunctionalConstraint,
+	trgOps TriggerOptions, arrayElements int) *DataAttribute {
+	return createDataAttribute(name, (*C.ModelNode)(unsafe.Pointer(do.object)), attributeType, fc, trgOps, arrayElements)
+}
+
+// CreateDataAttribute creates a sub attribute of an IEC61850_CONSTRUCTED DataAttribute
+func (da *DataAttribute) CreateDataAttribute(name string, attributeType DataAttributeType, fc FunctionalConstraint,
+	trgOps TriggerOptions, arrayElements int) *DataAttribute {
+	return createDataAttribute(name, (*C.ModelNode)(unsafe.Pointer(da.attribute)), attributeType, fc, trgOps, arrayElements)
+}
+
+func createDataAttribute(name string, parent *C.ModelNode, attributeType DataAttributeType, fc FunctionalConstraint,
+	trgOps TriggerOptions, arrayElements int) *DataAttribute {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+
+	return &DataAttribute{
+		attribute: C.DataAttribute_create(cName, parent, C.DataAttributeType(attributeType), C.FunctionalConstraint(fc),
+			C.uint8_t(trgOps), C.int(arrayElements), 0),
+	}
+}
+
 func (do *DataObject) GetChild(name string) *DataAttribute {
 	return &DataAttribute{
 		attribute: (*C.DataAttribute)(unsafe.Pointer(C.ModelNode_getChild((*C.ModelNode)(unsafe.Pointer(do.object)), C.CString(name)))),
@@ -103,3 +212,29 @@ func (ds *DataSet) AddDataSetEntry(ref string) {
 
 	C.DataSetEntry_create(ds.dataSet, cRef, -1, nil)
 }
+
+type ReportControlBlock struct {
+	rcb *C.ReportControlBlock
+}
+
+// CreateReportControlBlock creates a buffered or unbuffered report control block under this LogicalNode. An empty
+// rptId uses the object reference, dataSetName is the name of a DataSet of this LogicalNode or empty.
+func (ln *LogicalNode) CreateReportControlBlock(name string, rptId string, buffered bool, dataSetName string,
+	trgOps TriggerOptions, options ReportOptions, bufTm uint32, intgPd uint32) *ReportControlBlock {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+
+	var cRptId, cDataSetName *C.char
+	if rptId != "" {
+		cRptId = C.CString(rptId)
+		defer C.free(unsafe.Pointer(cRptId))
+	}
+	if dataSetName != "" {
+		cDataSetName = C.CString(dataSetName)
+		defer C.free(unsafe.Pointer(cDataSetName))
+	}
+
+	cRcb := C.ReportControlBlock_create(cName, ln.node, cRptId, C.bool(buffered), cDataSetName, 1,
+		C.uint8_t(trgOps), C.uint8_t(options), C.uint32_t(bufTm), C.uint32_t(intgPd))
+	return &ReportControlBlock{rcb: cRcb}
+}
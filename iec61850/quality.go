@@ -0,0 +1,94 @@
+package iec61850
+
+type Validity int
+
+const (
+	QUALITY_VALIDITY_GOOD         Validity = 0
+	QUALITY_VALIDITY_INVALID      Validity = 2
+	QUALITY_VALIDITY_RESERVED     Validity = 1
+	QUALITY_VALIDITY_QUESTIONABLE Validity = 3
+)
+
+// Quality bits in the same layout as the libiec61850 Quality type (bit 0 of the bit string is the lowest bit)
+type Quality uint16
+
+const (
+	QUALITY_DETAIL_OVERFLOW      Quality = 4
+	QUALITY_DETAIL_OUT_OF_RANGE  Quality = 8
+	QUALITY_DETAIL_BAD_REFERENCE Quality = 16
+	QUALITY_DETAIL_OSCILLATORY   Quality = 32
+	QUALITY_DETAIL_FAILURE       Quality = 64
+	QUALITY_DETAIL_OLD_DATA      Quality = 128
+	QUALITY_DETAIL_INCONSISTENT  Quality = 256
+	QUALITY_DETAIL_INACCURATE    Quality = 512
+	QUALITY_SOURCE_SUBSTITUTED   Quality = 1024
+	QUALITY_TEST                 Quality = 2048
+	QUALITY_OPERATOR_BLOCKED     Quality = 4096
+	QUALITY_DERIVED              Quality = 8192
+)
+
+// qualityBitSize the size of the quality bit string, edition 2.1 adds the derived bit
+const qualityBitSize = 13
+
+// qualityEdition21 not a quality bit, marks a q received as 14 bit string so it is written back with 14 bits even if
+// the derived bit is not set
+const qualityEdition21 Quality = 1 << 15
+
+func (Quality) Type() MMSType { return MMS_BIT_STRING }
+
+func (q Quality) Validity() Validity {
+	return Validity(q & 0x3)
+}
+
+func (q Quality) IsGood() bool {
+	return q.Validity() == QUALITY_VALIDITY_GOOD
+}
+
+// DetailQuality the detail quality bits only (overflow .. inaccurate)
+func (q Quality) DetailQuality() Quality {
+	return q & 0x3fc
+}
+
+func (q Quality) Has(flag Quality) bool {
+	return q&flag == flag
+}
+
+func (q Quality) Substituted() bool {
+	return q.Has(QUALITY_SOURCE_SUBSTITUTED)
+}
+
+func (q Quality) Test() bool {
+	return q.Has(QUALITY_TEST)
+}
+
+func (q Quality) OperatorBlocked() bool {
+	return q.Has(QUALITY_OPERATOR_BLOCKED)
+}
+
+// BitSize the size of the bit string of q: 14 if q was received with 14 bits or the derived bit is set, 13 otherwise
+func (q Quality) BitSize() int {
+	if q&qualityEdition21 != 0 || q.Has(QUALITY_DERIVED) {
+		return qualityBitSize + 1
+	}
+	return qualityBitSize
+}
+
+// Bits the quality bits of q without the bit size
+func (q Quality) Bits() Quality {
+	return q &^ qualityEdition21
+}
+
+func (q Quality) BitString() BitString {
+	return NewBitString(q.BitSize(), uint32(q.Bits()))
+}
+
+// qualityFromBitString the quality of a 13 or 14 bit string, the size is kept for writing it back
+func qualityFromBitString(bs BitString) (Quality, bool) {
+	switch bs.Size {
+	case qualityBitSize:
+		return Quality(bs.Uint32()), true
+	case qualityBitSize + 1:
+		return Quality(bs.Uint32()) | qualityEdition21, true
+	}
+	return 0, false
+}
@@ -25,7 +25,9 @@ type Unicode255 string
 
 type DataSetDetail struct {
 	DataSet
-	IEDName           string
+	IEDName string
+	// LDevices the logical devices of the IED, to find the LN types of the members
+	LDevices          []LDevice
 	DOTypes           map[string]DOType
 	DataTypeTemplates DataTypeTemplates
 }
@@ -74,6 +76,7 @@ func (ds *DataSetDetail) GetDOType(prefix, lnClass, name string) DOType {
 }
 
 type SCL struct {
+	Communication     Communication     `xml:"Communication"`
 	IED               []IED             `xml:"IED"`
 	DataTypeTemplates DataTypeTemplates `xml:"DataTypeTemplates"`
 }
@@ -92,6 +95,7 @@ func (scl *SCL) GetDataSet(ref string) (*DataSetDetail, error) {
 						if fmt.Sprintf("%s.%s", lDevice.LN0.LnClass, dSet.Name) == args[1] {
 							return &DataSetDetail{
 								IEDName:           ied.Name,
+								LDevices:          accessPoint.LDevice,
 								DataSet:           dSet,
 								DataTypeTemplates: scl.DataTypeTemplates,
 							}, nil
@@ -106,6 +110,52 @@ func (scl *SCL) GetDataSet(ref string) (*DataSetDetail, error) {
 	return nil, fmt.Errorf("can not found dataset ref: %s", ref)
 }
 
+// GetConnectedAP the communication parameters of the access point apName of the IED, the first access point of the
+// IED if apName is empty
+func (scl *SCL) GetConnectedAP(iedName, apName string) (*ConnectedAP, error) {
+	for _, subNetwork := range scl.Communication.SubNetwork {
+		for i, connectedAP := range subNetwork.ConnectedAP {
+			if connectedAP.IedName == iedName && (apName == "" || connectedAP.ApName == apName) {
+				return &subNetwork.ConnectedAP[i], nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("can not found connected access point: %s %s", iedName, apName)
+}
+
+type Communication struct {
+	SubNetwork []SubNetwork `xml:"SubNetwork"`
+}
+
+type SubNetwork struct {
+	Name        string        `xml:"name,attr"`
+	Type        string        `xml:"type,attr"`
+	ConnectedAP []ConnectedAP `xml:"ConnectedAP"`
+}
+
+type ConnectedAP struct {
+	IedName string `xml:"iedName,attr"`
+	ApName  string `xml:"apName,attr"`
+	Address []P    `xml:"Address>P"`
+}
+
+// P an address parameter, e.g. type "IP", "OSI-AP-Title", "OSI-AE-Qualifier", "OSI-PSEL", "OSI-SSEL", "OSI-TSEL"
+type P struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+// GetAddress the value of the address parameter of the given type
+func (ap *ConnectedAP) GetAddress(typ string) (string, bool) {
+	for _, p := range ap.Address {
+		if p.Type == typ {
+			return strings.TrimSpace(p.Value), true
+		}
+	}
+	return "", false
+}
+
 type IED struct {
 	Name          string        `xml:"name,attr"`
 	Type          string        `xml:"type,attr"`
@@ -205,6 +255,8 @@ type DOType struct {
 	DA   []DA   `xml:"DA"`
 	Desc string `xml:"desc,attr,omitempty"`
 	SDO  []SDO  `xml:"SDO"`
+	// Order the names of the SDOs and DAs in the order of the SCL, which is the order of the MMS components
+	Order []string `xml:"-"`
 }
 
 type DA struct {
@@ -213,6 +265,10 @@ type DA struct {
 	FC   string  `xml:"fc,attr"`
 	Val  DAValue `xml:"Val"`
 	DA   []DA    `xml:"DA"`
+	// TypeID the DAType of a Struct or the EnumType of an Enum
+	TypeID string `xml:"type,attr,omitempty"`
+	// Count the number of array elements, empty if not an array
+	Count string `xml:"count,attr,omitempty"`
 }
 
 type DAType struct {
@@ -222,9 +278,11 @@ type DAType struct {
 }
 
 type BDA struct {
-	Name string  `xml:"name,attr"`
-	Type string  `xml:"type,attr"`
-	Val  DAValue `xml:"Val"`
+	Name  string  `xml:"name,attr"`
+	Type  string  `xml:"type,attr"`
+	Val   DAValue `xml:"Val"`
+	BType string  `xml:"bType,attr"`
+	Count string  `xml:"count,attr,omitempty"`
 }
 
 type EnumType struct {
@@ -0,0 +1,268 @@
+package scl_xml
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// maxTypeDepth guards against recursive type definitions
+const maxTypeDepth = 16
+
+// TypeNode a (sub) data object, data attribute or component of an attribute resolved from the DataTypeTemplates.
+// The children are in the order of the SCL, which is the order of the MMS components.
+type TypeNode struct {
+	Name string
+	// DataObject whether the node is a data object or sub data object, its children have different FCs
+	DataObject bool
+	// FC the functional constraint of a data attribute, empty for data objects and components of attributes
+	FC string
+	// BType the basic type of an attribute or component, e.g. "FLOAT32", "Quality", "Struct", "Enum"
+	BType string
+	// Count the number of array elements, 0 if not an array
+	Count    int
+	Children []*TypeNode
+}
+
+// Child the child by name, nil if not found
+func (n *TypeNode) Child(name string) *TypeNode {
+	for _, child := range n.Children {
+		if child.Name == name {
+			return child
+		}
+	}
+	return nil
+}
+
+// HasFC whether the node is or contains a data attribute with the functional constraint
+func (n *TypeNode) HasFC(fc string) bool {
+	if !n.DataObject {
+		return n.FC == fc
+	}
+
+	for _, child := range n.Children {
+		if child.HasFC(fc) {
+			return true
+		}
+	}
+	return false
+}
+
+// Components the children which are components of the MMS value of the node with the functional constraint, all
+// children below a data attribute
+func (n *TypeNode) Components(fc string) []*TypeNode {
+	if !n.DataObject {
+		return n.Children
+	}
+
+	var components []*TypeNode
+	for _, child := range n.Children {
+		if child.HasFC(fc) {
+			components = append(components, child)
+		}
+	}
+	return components
+}
+
+// UnmarshalXML decode the DOType and keep the order of its SDOs and DAs in Order
+func (t *DOType) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for _, attr := range start.Attr {
+		switch attr.Name.Local {
+		case "id":
+			t.ID = attr.Value
+		case "desc":
+			t.Desc = attr.Value
+		}
+	}
+
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+
+		switch element := token.(type) {
+		case xml.StartElement:
+			switch element.Name.Local {
+			case "DA":
+				var da DA
+				if err := d.DecodeElement(&da, &element); err != nil {
+					return err
+				}
+				t.DA = append(t.DA, da)
+				t.Order = append(t.Order, da.Name)
+			case "SDO":
+				var sdo SDO
+				if err := d.DecodeElement(&sdo, &element); err != nil {
+					return err
+				}
+				t.SDO = append(t.SDO, sdo)
+				t.Order = append(t.Order, sdo.Name)
+			default:
+				if err := d.Skip(); err != nil {
+					return err
+				}
+			}
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+func (dt *DataTypeTemplates) findDOType(id string) (DOType, bool) {
+	for _, doType := range dt.DOType {
+		if doType.ID == id {
+			return doType, true
+		}
+	}
+	return DOType{}, false
+}
+
+func (dt *DataTypeTemplates) findDAType(id string) (DAType, bool) {
+	for _, daType := range dt.DAType {
+		if daType.ID == id {
+			return daType, true
+		}
+	}
+	return DAType{}, false
+}
+
+// ResolveDOType the type tree of a data object, following its SDOs, DAs, DATypes and BDAs
+func (dt *DataTypeTemplates) ResolveDOType(name string, doType DOType) *TypeNode {
+	return dt.resolveDOType(name, doType, 0)
+}
+
+func (dt *DataTypeTemplates) resolveDOType(name string, doType DOType, depth int) *TypeNode {
+	node := &TypeNode{Name: name, DataObject: true}
+	if depth > maxTypeDepth {
+		return node
+	}
+
+	order := doType.Order
+	if len(order) == 0 {
+		for _, sdo := range doType.SDO {
+			order = append(order, sdo.Name)
+		}
+		for _, da := range doType.DA {
+			order = append(order, da.Name)
+		}
+	}
+
+	for _, name := range order {
+		if child := dt.resolveDOTypeChild(name, doType, depth); child != nil {
+			node.Children = append(node.Children, child)
+		}
+	}
+
+	return node
+}
+
+func (dt *DataTypeTemplates) resolveDOTypeChild(name string, doType DOType, depth int) *TypeNode {
+	for _, sdo := range doType.SDO {
+		if sdo.Name == name {
+			subType, ok := dt.findDOType(sdo.Type)
+			if !ok {
+				return &TypeNode{Name: sdo.Name, DataObject: true}
+			}
+			return dt.resolveDOType(sdo.Name, subType, depth+1)
+		}
+	}
+
+	for _, da := range doType.DA {
+		if da.Name == name {
+			return dt.resolveAttribute(da.Name, da.FC, da.Type, da.TypeID, da.Count, depth+1)
+		}
+	}
+
+	return nil
+}
+
+func (dt *DataTypeTemplates) resolveAttribute(name, fc, bType, typeID, count string, depth int) *TypeNode {
+	node := &TypeNode{Name: name, FC: fc, BType: bType}
+	node.Count, _ = strconv.Atoi(count)
+
+	if bType != "Struct" || depth > maxTypeDepth {
+		return node
+	}
+
+	daType, ok := dt.findDAType(typeID)
+	if !ok {
+		return node
+	}
+
+	for _, bda := range daType.BDA {
+		node.Children = append(node.Children, dt.resolveAttribute(bda.Name, "", bda.BType, bda.Type, bda.Count, depth+1))
+	}
+
+	return node
+}
+
+// lnDOType the DOType of a data object following the lnType of the logical node of the member
+func (ds *DataSetDetail) lnDOType(fcda FCDAEntry, doName string) (DOType, bool) {
+	var lnType string
+	for _, lDevice := range ds.LDevices {
+		if lDevice.Inst != fcda.LDInst {
+			continue
+		}
+
+		if fcda.LNClass == "LLN0" {
+			lnType = lDevice.LN0.LnType
+			break
+		}
+		for _, ln := range lDevice.LN {
+			if ln.Prefix == fcda.Prefix && ln.LnClass == fcda.LNClass && ln.Inst == fcda.LNInst {
+				lnType = ln.LnType
+				break
+			}
+		}
+		break
+	}
+
+	if lnType == "" {
+		return DOType{}, false
+	}
+
+	for _, lNodeType := range ds.DataTypeTemplates.LNodeType {
+		if lNodeType.ID != lnType {
+			continue
+		}
+		for _, do := range lNodeType.DO {
+			if do.Name == doName {
+				return ds.DataTypeTemplates.findDOType(do.Type)
+			}
+		}
+		break
+	}
+
+	return DOType{}, false
+}
+
+// ResolveFCDA the type tree of a data set member, e.g. of the DO for doName "A.phsA" or of the DA for daName "mag.f".
+// The DOType is found by the lnType of the logical node, by GetDOType if the logical node is not in LDevices.
+func (ds *DataSetDetail) ResolveFCDA(fcda FCDAEntry) (*TypeNode, error) {
+	names := strings.Split(fcda.DOName, ".")
+
+	doType, ok := ds.lnDOType(fcda, names[0])
+	if !ok {
+		doType = ds.GetDOType(fcda.Prefix, fcda.LNClass, names[0])
+		if doType.ID == "" {
+			return nil, fmt.Errorf("can not found DOType of %s%s%s.%s", fcda.Prefix, fcda.LNClass, fcda.LNInst, fcda.DOName)
+		}
+	}
+
+	node := ds.DataTypeTemplates.ResolveDOType(names[0], doType)
+
+	if fcda.DAName != "" {
+		names = append(names, strings.Split(fcda.DAName, ".")...)
+	}
+	for _, name := range names[1:] {
+		child := node.Child(name)
+		if child == nil {
+			return nil, fmt.Errorf("can not found %s of %s%s%s.%s", name, fcda.Prefix, fcda.LNClass, fcda.LNInst, fcda.DOName)
+		}
+		node = child
+	}
+
+	return node, nil
+}
@@ -0,0 +1,88 @@
+package test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/weiheng-tech/go-libiec61850/iec61850"
+	"github.com/weiheng-tech/go-libiec61850/iec61850/scl_xml"
+)
+
+func TestIEC61850IsoAddressFromSCL(t *testing.T) {
+	scl, err := scl_xml.GetSCL("test_icd.icd")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	connectedAP, err := scl.GetConnectedAP("Huwor_JF204", "S1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ip, _ := connectedAP.GetAddress("IP"); ip != "127.0.0.1" {
+		t.Errorf("unexpected ip: %s", ip)
+	}
+
+	connectedAP.Address = append(connectedAP.Address,
+		scl_xml.P{Type: "OSI-AP-Title", Value: "1,1,1,999"},
+		scl_xml.P{Type: "OSI-AE-Qualifier", Value: "12"},
+		scl_xml.P{Type: "OSI-PSEL", Value: "00000001"},
+		scl_xml.P{Type: "OSI-SSEL", Value: "0001"},
+		scl_xml.P{Type: "OSI-TSEL", Value: "0001"},
+	)
+
+	address, err := iec61850.IsoAddressFromSCL(connectedAP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if address.ApTitle != "1.1.1.999" || address.AeQualifier != 12 {
+		t.Errorf("unexpected ap-title: %s, ae-qualifier: %d", address.ApTitle, address.AeQualifier)
+	}
+	if !bytes.Equal(address.PSelector, []byte{0, 0, 0, 1}) || !bytes.Equal(address.SSelector, []byte{0, 1}) || !bytes.Equal(address.TSelector, []byte{0, 1}) {
+		t.Errorf("unexpected selectors: %x %x %x", address.PSelector, address.SSelector, address.TSelector)
+	}
+}
+
+func TestIEC61850ClientIsoAddress(t *testing.T) {
+	tcpPort := 10212
+
+	model := iec61850.NewIedModel("test")
+	defer model.Destroy()
+	lDevice1 := model.CreateLogicalDevice("SENSORS")
+	lln0 := lDevice1.CreateLogicalNode("LLN0")
+	lln0.CreateDataObjectCDC_SAV("FLOAT", false)
+
+	server := iec61850.NewIedServer(model)
+	defer server.Destroy()
+	server.Start(tcpPort)
+	defer server.Stop()
+
+	client := iec61850.NewIedClient(
+		iec61850.LocalAddress(iec61850.IsoAddress{ApTitle: "1.1.1.998", AeQualifier: 13}),
+		iec61850.RemoteAddress(iec61850.IsoAddress{
+			ApTitle:     "1.1.1.999",
+			AeQualifier: 12,
+			PSelector:   []byte{0, 0, 0, 1},
+			SSelector:   []byte{0, 1},
+			TSelector:   []byte{0, 1},
+		}),
+	)
+	defer client.Close()
+
+	if err := client.Connect("localhost", tcpPort); err != nil {
+		t.Fatal(err)
+	}
+
+	invalid := iec61850.NewIedClient(iec61850.RemoteAddress(iec61850.IsoAddress{TSelector: []byte{0, 0, 0, 0, 1}}))
+	defer invalid.Close()
+
+	if err := invalid.Connect("localhost", tcpPort); err == nil {
+		t.Error("connect with an invalid t-selector succeeded")
+	}
+
+	withoutApTitle := iec61850.NewIedClient(iec61850.LocalAddress(iec61850.IsoAddress{AeQualifier: 13}))
+	defer withoutApTitle.Close()
+
+	if err := withoutApTitle.Connect("localhost", tcpPort); err == nil {
+		t.Error("connect with an ae-qualifier without ap-title succeeded")
+	}
+}
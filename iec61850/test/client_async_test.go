@@ -0,0 +1,84 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/weiheng-tech/go-libiec61850/iec61850"
+)
+
+func TestIEC61850ClientReadAsync(t *testing.T) {
+	tcpPort := 10204
+
+	model := iec61850.NewIedModel("test")
+	defer model.Destroy()
+	lDevice1 := model.CreateLogicalDevice("SENSORS")
+	lln0 := lDevice1.CreateLogicalNode("LLN0")
+	dataF := lln0.CreateDataObjectCDC_SAV("FLOAT", false)
+	dataset := lln0.CreateDataSet("DATASET")
+	dataset.AddDataSetEntry("LLN0$MX$FLOAT$instMag$f")
+
+	server := iec61850.NewIedServer(model)
+	defer server.Destroy()
+	server.Start(tcpPort)
+	defer server.Stop()
+
+	server.LockDataModel()
+	server.UpdateFloatAttributeValue(dataF.GetChild("instMag.f"), 3.5)
+	server.UnlockDataModel()
+
+	client := iec61850.NewIedClient()
+	err := client.Connect("localhost", tcpPort)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	// several requests outstanding on one connection
+	readCall, err := client.ReadObjectAsync("testSENSORS/LLN0.FLOAT.instMag.f", iec61850.IEC61850_FC_MX, time.Second, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dataSetResults := make(chan iec61850.AsyncResult, 1)
+	dataSetCall, err := client.ReadDataSetValuesAsync("testSENSORS/LLN0.DATASET", time.Second, func(result iec61850.AsyncResult) {
+		dataSetResults <- result
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := <-readCall.Done()
+	if result.Err != nil || result.Value != iec61850.Float32(3.5) {
+		t.Errorf("unexpected read result: %+v", result)
+	}
+	if result.InvokeId != readCall.InvokeId {
+		t.Errorf("unexpected invoke id: %d, expect: %d", result.InvokeId, readCall.InvokeId)
+	}
+
+	result = <-dataSetResults
+	if result.Err != nil || len(result.Values) != 1 || result.Values[0] != iec61850.Float32(3.5) {
+		t.Errorf("unexpected dataset result: %+v", result)
+	}
+	if result.InvokeId != dataSetCall.InvokeId {
+		t.Errorf("unexpected invoke id: %d, expect: %d", result.InvokeId, dataSetCall.InvokeId)
+	}
+
+	specCall, err := client.GetTypeSpecAsync("testSENSORS/LLN0.FLOAT", iec61850.IEC61850_FC_MX, time.Second, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result = <-specCall.Done()
+	if result.Err != nil || result.Spec == nil || result.Spec.Type != iec61850.MMS_STRUCTURE {
+		t.Errorf("unexpected type spec result: %+v", result)
+	}
+
+	directoryCall, err := client.GetDataSetDirectoryAsync("testSENSORS/LLN0.DATASET", time.Second, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result = <-directoryCall.Done()
+	if result.Err != nil || result.Directory == nil || len(result.Directory.Members) != 1 {
+		t.Errorf("unexpected dataset directory result: %+v", result)
+	}
+}
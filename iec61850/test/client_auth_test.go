@@ -0,0 +1,131 @@
+package test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/weiheng-tech/go-libiec61850/iec61850"
+)
+
+func TestIEC61850AcsePasswordAuthentication(t *testing.T) {
+	tcpPort := 10211
+
+	model := iec61850.NewIedModel("test")
+	defer model.Destroy()
+	lDevice1 := model.CreateLogicalDevice("SENSORS")
+	lln0 := lDevice1.CreateLogicalNode("LLN0")
+	lln0.CreateDataObjectCDC_SAV("FLOAT", false)
+
+	server := iec61850.NewIedServer(model)
+	defer server.Destroy()
+
+	peerAddresses := make(chan string, 2)
+	server.SetAuthenticator(func(credential iec61850.Credential) (bool, interface{}) {
+		peerAddresses <- credential.PeerAddress
+		if credential.Mechanism != iec61850.ACSE_AUTH_PASSWORD || string(credential.Value) != "secret" {
+			return false, nil
+		}
+		return true, "operator"
+	})
+
+	connections := make(chan iec61850.ClientConnection, 1)
+	server.OnConnection(func(connection iec61850.ClientConnection, connected bool) {
+		if connected {
+			connections <- connection
+		}
+	})
+
+	server.Start(tcpPort)
+	defer server.Stop()
+
+	client := iec61850.NewIedClient(iec61850.AcsePassword("secret"))
+	defer client.Close()
+
+	err := client.Connect("localhost", tcpPort)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case connection := <-connections:
+		if connection.SecurityToken != "operator" {
+			t.Errorf("unexpected security token: %v", connection.SecurityToken)
+		}
+		if connection.PeerAddress == "" {
+			t.Error("missing peer address")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no connection indication received")
+	}
+
+	if peerAddress := <-peerAddresses; peerAddress == "" {
+		t.Error("missing peer address of the credential")
+	}
+
+	rejected := iec61850.NewIedClient(iec61850.AcsePassword("wrong"))
+	defer rejected.Close()
+
+	if err := rejected.Connect("localhost", tcpPort); err == nil {
+		t.Error("connection with a wrong password succeeded")
+	}
+}
+
+func TestIEC61850ServerAccessHandlers(t *testing.T) {
+	tcpPort := 10227
+
+	model := iec61850.NewIedModel("test")
+	defer model.Destroy()
+	lDevice1 := model.CreateLogicalDevice("SENSORS")
+	lln0 := lDevice1.CreateLogicalNode("LLN0")
+	lln0.CreateDataObjectCDC_SAV("FLOAT", false)
+	setVal := lln0.CreateDataObject("NAME").CreateDataAttribute("setVal", iec61850.IEC61850_VISIBLE_STRING_255, iec61850.IEC61850_FC_SP, 0, 0)
+
+	server := iec61850.NewIedServer(model)
+	defer server.Destroy()
+
+	server.SetAuthenticator(func(credential iec61850.Credential) (bool, interface{}) {
+		return true, string(credential.Value)
+	})
+
+	reads := make(chan string, 8)
+	server.OnReadAccess(func(connection iec61850.ClientConnection, objectRef string, constraint iec61850.FunctionalConstraint) bool {
+		reads <- objectRef
+		return connection.SecurityToken == "operator"
+	})
+	server.OnWriteAccess(setVal, func(connection iec61850.ClientConnection, value iec61850.MmsValue) bool {
+		return connection.SecurityToken == "operator" && value == iec61850.VisibleString("renamed")
+	})
+
+	server.Start(tcpPort)
+	defer server.Stop()
+
+	operator := iec61850.NewIedClient(iec61850.AcsePassword("operator"))
+	defer operator.Close()
+	if err := operator.Connect("localhost", tcpPort); err != nil {
+		t.Fatal(err)
+	}
+
+	guest := iec61850.NewIedClient(iec61850.AcsePassword("guest"))
+	defer guest.Close()
+	if err := guest.Connect("localhost", tcpPort); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := operator.ReadObject("testSENSORS/LLN0.FLOAT.instMag.f", iec61850.IEC61850_FC_MX); err != nil {
+		t.Errorf("operator read failed: %v", err)
+	}
+	if objectRef := <-reads; objectRef != "testSENSORS/LLN0.FLOAT" {
+		t.Errorf("unexpected object reference: %s", objectRef)
+	}
+	if _, err := guest.ReadObject("testSENSORS/LLN0.FLOAT.instMag.f", iec61850.IEC61850_FC_MX); !errors.Is(err, iec61850.ErrAccessDenied) {
+		t.Errorf("unexpected guest read error: %v", err)
+	}
+
+	if err := operator.WriteObject("testSENSORS/LLN0.NAME.setVal", iec61850.IEC61850_FC_SP, iec61850.VisibleString("renamed")); err != nil {
+		t.Errorf("operator write failed: %v", err)
+	}
+	if err := guest.WriteObject("testSENSORS/LLN0.NAME.setVal", iec61850.IEC61850_FC_SP, iec61850.VisibleString("renamed")); !errors.Is(err, iec61850.ErrAccessDenied) {
+		t.Errorf("unexpected guest write error: %v", err)
+	}
+}
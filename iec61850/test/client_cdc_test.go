@@ -0,0 +1,142 @@
+package test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/weiheng-tech/go-libiec61850/iec61850"
+)
+
+func TestIEC61850ClientReadCDC(t *testing.T) {
+	tcpPort := 10222
+
+	model := iec61850.NewIedModel("test")
+	defer model.Destroy()
+	lDevice1 := model.CreateLogicalDevice("GenericIO")
+	lln0 := lDevice1.CreateLogicalNode("LLN0")
+	mod := lln0.CreateDataObjectCDC_INS("Mod")
+	ggio := lDevice1.CreateLogicalNode("GGIO1")
+	anIn1 := ggio.CreateDataObjectCDC_MV("AnIn1", false)
+	ind1 := ggio.CreateDataObjectCDC_SPS("Ind1")
+	pos := ggio.CreateDataObjectCDC_DPS("Pos")
+	mmxu := lDevice1.CreateLogicalNode("MMXU1")
+	totVA := mmxu.CreateDataObjectCDC_CMV("TotVA")
+	phV := mmxu.CreateDataObjectCDC_WYE("PhV")
+
+	server := iec61850.NewIedServer(model)
+	defer server.Destroy()
+	server.Start(tcpPort)
+	defer server.Stop()
+
+	server.LockDataModel()
+	server.UpdateInt32AttributeValue(mod.GetChild("stVal"), 1)
+	server.UpdateFloatAttributeValue(anIn1.GetChild("mag.f"), 12.5)
+	server.UpdateUTCTimeAttributeValue(anIn1.GetChild("t"), 1700000000123)
+	server.UpdateBooleanAttributeValue(ind1.GetChild("stVal"), true)
+	server.UpdateDbposValue(pos.GetChild("stVal"), iec61850.DBPOS_ON)
+	server.UpdateFloatAttributeValue(totVA.GetChild("cVal.mag.f"), 230.5)
+	server.UpdateFloatAttributeValue(totVA.GetChild("cVal.ang.f"), -30)
+	server.UpdateFloatAttributeValue(phV.GetChild("phsA.cVal.mag.f"), 231)
+	server.UpdateFloatAttributeValue(phV.GetChild("phsB.cVal.ang.f"), 120)
+	server.UnlockDataModel()
+
+	client := iec61850.NewIedClient()
+	defer client.Close()
+	if err := client.Connect("localhost", tcpPort); err != nil {
+		t.Fatal(err)
+	}
+
+	mv, err := client.ReadMV("testGenericIO/GGIO1.AnIn1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mv.Mag != 12.5 || mv.T.Time().UnixMilli() != 1700000000123 {
+		t.Errorf("unexpected AnIn1: %+v", mv)
+	}
+
+	sps, err := client.ReadSPS("testGenericIO/GGIO1.Ind1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sps.StVal {
+		t.Errorf("unexpected Ind1: %+v", sps)
+	}
+
+	ins, err := client.ReadINS("testGenericIO/LLN0.Mod")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ins.StVal != 1 {
+		t.Errorf("unexpected Mod: %+v", ins)
+	}
+
+	for _, dbpos := range []iec61850.Dbpos{iec61850.DBPOS_ON, iec61850.DBPOS_OFF, iec61850.DBPOS_BAD_STATE, iec61850.DBPOS_INTERMEDIATE_STATE} {
+		server.LockDataModel()
+		server.UpdateDbposValue(pos.GetChild("stVal"), dbpos)
+		server.UnlockDataModel()
+
+		dps, err := client.ReadDPS("testGenericIO/GGIO1.Pos")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if dps.StVal != dbpos {
+			t.Errorf("expect Pos %d, got %d", dbpos, dps.StVal)
+		}
+	}
+
+	cmv, err := client.ReadCMV("testGenericIO/MMXU1.TotVA")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cmv.Mag != 230.5 || cmv.Ang != -30 {
+		t.Errorf("unexpected TotVA: %+v", cmv)
+	}
+
+	wye, err := client.ReadWYE("testGenericIO/MMXU1.PhV")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wye.PhsA.Mag != 231 || math.Abs(wye.PhsB.Ang-120) > 1e-6 || wye.PhsC.Mag != 0 {
+		t.Errorf("unexpected PhV: %+v", wye)
+	}
+}
+
+func TestIEC61850ClientReadCDCWithoutQuality(t *testing.T) {
+	tcpPort := 10226
+
+	// a MV without q
+	model := iec61850.NewIedModel("test")
+	defer model.Destroy()
+	lDevice1 := model.CreateLogicalDevice("GenericIO")
+	lDevice1.CreateLogicalNode("LLN0")
+	ggio := lDevice1.CreateLogicalNode("GGIO1")
+	anIn1 := ggio.CreateDataObject("AnIn1")
+	mag := anIn1.CreateDataAttribute("mag", iec61850.IEC61850_CONSTRUCTED, iec61850.IEC61850_FC_MX, iec61850.TRG_OPT_DATA_CHANGED, 0)
+	f := mag.CreateDataAttribute("f", iec61850.IEC61850_FLOAT32, iec61850.IEC61850_FC_MX, iec61850.TRG_OPT_DATA_CHANGED, 0)
+
+	server := iec61850.NewIedServer(model)
+	defer server.Destroy()
+	server.Start(tcpPort)
+	defer server.Stop()
+
+	server.LockDataModel()
+	server.UpdateFloatAttributeValue(f, 12.5)
+	server.UnlockDataModel()
+
+	client := iec61850.NewIedClient()
+	defer client.Close()
+	if err := client.Connect("localhost", tcpPort); err != nil {
+		t.Fatal(err)
+	}
+
+	mv, err := client.ReadMV("testGenericIO/GGIO1.AnIn1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mv.Mag != 12.5 {
+		t.Errorf("unexpected AnIn1: %+v", mv)
+	}
+	if mv.Q.Validity() != iec61850.QUALITY_VALIDITY_INVALID {
+		t.Errorf("expect an invalid quality without q, got %v", mv.Q.Validity())
+	}
+}
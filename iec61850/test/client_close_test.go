@@ -0,0 +1,57 @@
+package test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/weiheng-tech/go-libiec61850/iec61850"
+)
+
+func TestIEC61850ClientConcurrentClose(t *testing.T) {
+	tcpPort := 10209
+
+	model := iec61850.NewIedModel("test")
+	defer model.Destroy()
+	lDevice1 := model.CreateLogicalDevice("SENSORS")
+	lln0 := lDevice1.CreateLogicalNode("LLN0")
+	lln0.CreateDataObjectCDC_SAV("FLOAT", false)
+	dataset := lln0.CreateDataSet("DATASET")
+	dataset.AddDataSetEntry("LLN0$MX$FLOAT$instMag$f")
+
+	server := iec61850.NewIedServer(model)
+	defer server.Destroy()
+	server.Start(tcpPort)
+	defer server.Stop()
+
+	client := iec61850.NewIedClient()
+	err := client.Connect("localhost", tcpPort)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				if _, err := client.ReadDataSetValues("testSENSORS/LLN0.DATASET", ""); errors.Is(err, iec61850.ErrClientClosed) {
+					return
+				}
+			}
+		}()
+	}
+
+	client.Close()
+	client.Close()
+	wg.Wait()
+
+	_, err = client.ReadObject("testSENSORS/LLN0.FLOAT.instMag.f", iec61850.IEC61850_FC_MX)
+	if !errors.Is(err, iec61850.ErrClientClosed) {
+		t.Errorf("unexpected error: %v, expect: %v", err, iec61850.ErrClientClosed)
+	}
+	if client.State() != iec61850.IED_STATE_CLOSED {
+		t.Errorf("unexpected state: %v", client.State())
+	}
+}
@@ -0,0 +1,108 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/weiheng-tech/go-libiec61850/iec61850"
+)
+
+func TestIEC61850ClientContext(t *testing.T) {
+	tcpPort := 10205
+
+	model := iec61850.NewIedModel("test")
+	defer model.Destroy()
+	lDevice1 := model.CreateLogicalDevice("SENSORS")
+	lln0 := lDevice1.CreateLogicalNode("LLN0")
+	dataF := lln0.CreateDataObjectCDC_SAV("FLOAT", false)
+
+	server := iec61850.NewIedServer(model)
+	defer server.Destroy()
+	server.Start(tcpPort)
+	defer server.Stop()
+
+	server.LockDataModel()
+	server.UpdateFloatAttributeValue(dataF.GetChild("instMag.f"), 1.5)
+	server.UnlockDataModel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	client := iec61850.NewIedClient()
+	err := client.ConnectContext(ctx, "localhost", tcpPort)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	value, err := client.ReadContext(ctx, "testSENSORS/LLN0.FLOAT.instMag.f", iec61850.IEC61850_FC_MX)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != iec61850.Float32(1.5) {
+		t.Errorf("unexpected value: %v", value)
+	}
+
+	cancelled, cancelNow := context.WithCancel(context.Background())
+	cancelNow()
+
+	_, err = client.ReadContext(cancelled, "testSENSORS/LLN0.FLOAT.instMag.f", iec61850.IEC61850_FC_MX)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("unexpected error: %v, expect: %v", err, context.Canceled)
+	}
+
+	// the locked data model holds back the response, the context is cancelled while the read is outstanding
+	server.LockDataModel()
+	pending, cancelPending := context.WithCancel(context.Background())
+	time.AfterFunc(200*time.Millisecond, cancelPending)
+
+	start := time.Now()
+	_, err = client.ReadContext(pending, "testSENSORS/LLN0.FLOAT.instMag.f", iec61850.IEC61850_FC_MX)
+	elapsed := time.Since(start)
+	server.UnlockDataModel()
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("unexpected error: %v, expect: %v", err, context.Canceled)
+	}
+	if elapsed < 200*time.Millisecond {
+		t.Errorf("returned after %v, before the context was cancelled", elapsed)
+	}
+
+	server.LockDataModel()
+	deadline, cancelDeadline := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	_, err = client.ReadContext(deadline, "testSENSORS/LLN0.FLOAT.instMag.f", iec61850.IEC61850_FC_MX)
+	cancelDeadline()
+	server.UnlockDataModel()
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("unexpected error: %v, expect: %v", err, context.DeadlineExceeded)
+	}
+
+	// the late responses are dropped, the connection is still usable
+	value, err = client.ReadContext(ctx, "testSENSORS/LLN0.FLOAT.instMag.f", iec61850.IEC61850_FC_MX)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != iec61850.Float32(1.5) {
+		t.Errorf("unexpected value: %v", value)
+	}
+}
+
+func TestIEC61850ClientConnectContextRejected(t *testing.T) {
+	// no server listens on the port
+	tcpPort := 10225
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	client := iec61850.NewIedClient()
+	defer client.Close()
+
+	err := client.ConnectContext(ctx, "localhost", tcpPort)
+	var clientError *iec61850.ClientError
+	if !errors.As(err, &clientError) || !errors.Is(err, iec61850.ErrConnectionRejected) {
+		t.Errorf("unexpected error: %v, expect: %v", err, iec61850.ErrConnectionRejected)
+	}
+}
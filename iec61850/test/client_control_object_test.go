@@ -0,0 +1,89 @@
+package test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/weiheng-tech/go-libiec61850/iec61850"
+)
+
+func TestIEC61850ClientControlObject(t *testing.T) {
+	tcpPort := 10220
+
+	model := iec61850.NewIedModel("test")
+	defer model.Destroy()
+	lDevice1 := model.CreateLogicalDevice("CTRL")
+	lDevice1.CreateLogicalNode("LLN0")
+	ggio := lDevice1.CreateLogicalNode("GGIO1")
+	ggio.CreateDataObjectCDC_SPC("SPCSO1", int(iec61850.CONTROL_MODEL_DIRECT_NORMAL))
+	ggio.CreateDataObjectCDC_SPC("SPCSO2", int(iec61850.CONTROL_MODEL_SBO_NORMAL))
+	ggio.CreateDataObjectCDC_SPC("SPCSO3", int(iec61850.CONTROL_MODEL_DIRECT_ENHANCED))
+	ggio.CreateDataObjectCDC_SPC("SPCSO4", int(iec61850.CONTROL_MODEL_SBO_ENHANCED))
+
+	server := iec61850.NewIedServer(model)
+	defer server.Destroy()
+	server.Start(tcpPort)
+	defer server.Stop()
+
+	client := iec61850.NewIedClient()
+	defer client.Close()
+	if err := client.Connect("localhost", tcpPort); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		ref      string
+		ctlModel iec61850.ControlModel
+	}{
+		{"testCTRL/GGIO1.SPCSO1", iec61850.CONTROL_MODEL_DIRECT_NORMAL},
+		{"testCTRL/GGIO1.SPCSO2", iec61850.CONTROL_MODEL_SBO_NORMAL},
+		{"testCTRL/GGIO1.SPCSO3", iec61850.CONTROL_MODEL_DIRECT_ENHANCED},
+		{"testCTRL/GGIO1.SPCSO4", iec61850.CONTROL_MODEL_SBO_ENHANCED},
+	} {
+		t.Run(tc.ctlModel.String(), func(t *testing.T) {
+			control, err := client.NewControlObject(tc.ref)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer control.Close()
+
+			if control.ControlModel() != tc.ctlModel {
+				t.Fatalf("expect ctlModel %v, got %v", tc.ctlModel, control.ControlModel())
+			}
+
+			control.SetOrigin("test", 2)
+			if err := control.Control(iec61850.Boolean(true)); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+
+	sbo, err := client.NewControlObject("testCTRL/GGIO1.SPCSO2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sbo.Close()
+
+	err = sbo.Operate(iec61850.Boolean(false))
+	var controlError *iec61850.ControlError
+	if !errors.As(err, &controlError) {
+		t.Fatalf("expect ControlError, got %v", err)
+	}
+	if !errors.Is(err, iec61850.ADD_CAUSE_OBJECT_NOT_SELECTED) {
+		t.Errorf("expect ADD_CAUSE_OBJECT_NOT_SELECTED, got %v", controlError.LastApplError.AddCause)
+	}
+
+	if err := sbo.Select(); err != nil {
+		t.Fatal(err)
+	}
+	if err := sbo.Cancel(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := sbo.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := sbo.Operate(iec61850.Boolean(false)); !errors.Is(err, iec61850.ErrObjectInvalidated) {
+		t.Errorf("expect ErrObjectInvalidated after Close, got %v", err)
+	}
+}
@@ -0,0 +1,96 @@
+package test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/weiheng-tech/go-libiec61850/iec61850"
+)
+
+func TestIEC61850ClientDataSetReader(t *testing.T) {
+	tcpPort := 10216
+
+	model := iec61850.NewIedModel("test")
+	defer model.Destroy()
+	lDevice1 := model.CreateLogicalDevice("SENSORS")
+	lln0 := lDevice1.CreateLogicalNode("LLN0")
+	dataF := lln0.CreateDataObjectCDC_SAV("FLOAT", false)
+	dataI := lln0.CreateDataObjectCDC_SAV("INT", true)
+
+	server := iec61850.NewIedServer(model)
+	defer server.Destroy()
+	server.Start(tcpPort)
+	defer server.Stop()
+
+	server.LockDataModel()
+	server.UpdateFloatAttributeValue(dataF.GetChild("instMag.f"), 3.5)
+	server.UpdateInt32AttributeValue(dataI.GetChild("instMag.i"), 42)
+	server.UnlockDataModel()
+
+	client := iec61850.NewIedClient()
+	defer client.Close()
+
+	err := client.Connect("localhost", tcpPort)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = client.CreateDataSet("@poll", []iec61850.FCDA{
+		{Ref: "testSENSORS/LLN0.FLOAT.instMag.f", FC: iec61850.IEC61850_FC_MX},
+		{Ref: "testSENSORS/LLN0.INT.instMag.i", FC: iec61850.IEC61850_FC_MX},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reader := client.NewDataSetReader("@poll")
+	defer reader.Close()
+
+	values, changed, err := reader.Read(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(values, []iec61850.MmsValue{iec61850.Float32(3.5), iec61850.Integer(42)}) {
+		t.Errorf("unexpected values: %v", values)
+	}
+	if !reflect.DeepEqual(changed, []int{0, 1}) {
+		t.Errorf("expect all members changed on first read, got %v", changed)
+	}
+
+	values, changed, err = reader.Read(changed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changed) != 0 {
+		t.Errorf("expect no member changed, got %v", changed)
+	}
+
+	// unchanged members are not decoded again
+	allocs := testing.AllocsPerRun(10, func() {
+		values, changed, err = reader.Read(changed)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if allocs != 0 {
+		t.Errorf("expect no allocation for an unchanged read, got %v", allocs)
+	}
+
+	server.LockDataModel()
+	server.UpdateInt32AttributeValue(dataI.GetChild("instMag.i"), 43)
+	server.UnlockDataModel()
+
+	values, changed, err = reader.Read(changed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(changed, []int{1}) || values[0] != iec61850.Float32(3.5) || values[1] != iec61850.Integer(43) {
+		t.Errorf("unexpected values %v, changed: %v", values, changed)
+	}
+
+	reader.Close()
+	if _, _, err := reader.Read(changed); !errors.Is(err, iec61850.ErrReaderClosed) {
+		t.Errorf("expect ErrReaderClosed, got %v", err)
+	}
+}
@@ -0,0 +1,93 @@
+package test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/weiheng-tech/go-libiec61850/iec61850"
+)
+
+func TestIEC61850ClientDataSetServices(t *testing.T) {
+	tcpPort := 10215
+
+	model := iec61850.NewIedModel("test")
+	defer model.Destroy()
+	lDevice1 := model.CreateLogicalDevice("SENSORS")
+	lln0 := lDevice1.CreateLogicalNode("LLN0")
+	lln0.CreateDataObjectCDC_SAV("FLOAT", false)
+	lln0.CreateDataObjectCDC_SAV("INT", true)
+
+	server := iec61850.NewIedServer(model)
+	defer server.Destroy()
+	server.Start(tcpPort)
+	defer server.Stop()
+
+	client := iec61850.NewIedClient()
+	defer client.Close()
+
+	err := client.Connect("localhost", tcpPort)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	members := []iec61850.FCDA{
+		{Ref: "testSENSORS/LLN0.FLOAT.instMag.f", FC: iec61850.IEC61850_FC_MX},
+		{Ref: "testSENSORS/LLN0.INT", FC: iec61850.IEC61850_FC_MX},
+	}
+
+	for _, ref := range []string{"testSENSORS/LLN0.dynamic", "@dynamic"} {
+		if err := client.CreateDataSet(ref, members); err != nil {
+			t.Fatal(err)
+		}
+
+		directory, err := client.GetDataSetDirectory(ref)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !directory.Deletable || len(directory.Members) != len(members) {
+			t.Fatalf("unexpected directory of %s: %+v", ref, directory)
+		}
+		for i, member := range directory.Members {
+			if member != members[i] {
+				t.Errorf("member %d of %s: expect %v, got %v", i, ref, members[i], member)
+			}
+		}
+
+		values, err := client.ReadDataSetValues(ref, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(values) != len(members) {
+			t.Errorf("expect %d values of %s, got %d", len(members), ref, len(values))
+		}
+
+		if err := client.DeleteDataSet(ref); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := client.GetDataSetDirectory(ref); err == nil {
+			t.Errorf("directory of deleted data set %s", ref)
+		}
+	}
+
+	err = client.CreateDataSet("testSENSORS/LLN0.invalid", []iec61850.FCDA{{Ref: "testSENSORS/LLN0.NOTEXIST", FC: iec61850.IEC61850_FC_MX}})
+	var clientError *iec61850.ClientError
+	if !errors.As(err, &clientError) {
+		t.Errorf("expect ClientError, got %v", err)
+	}
+}
+
+func TestIEC61850ParseFCDA(t *testing.T) {
+	fcda, err := iec61850.ParseFCDA("LD0/MMXU1.TotW.mag.f[MX]")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fcda.Ref != "LD0/MMXU1.TotW.mag.f" || fcda.FC != iec61850.IEC61850_FC_MX || fcda.String() != "LD0/MMXU1.TotW.mag.f[MX]" {
+		t.Errorf("unexpected FCDA: %+v", fcda)
+	}
+
+	for _, ref := range []string{"LD0/MMXU1.TotW", "LD0/MMXU1.TotW[XX]", "[MX]"} {
+		if _, err := iec61850.ParseFCDA(ref); err == nil {
+			t.Errorf("parsed invalid reference %s", ref)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/weiheng-tech/go-libiec61850/iec61850"
+)
+
+func TestIEC61850ClientErrors(t *testing.T) {
+	tcpPort := 10206
+
+	model := iec61850.NewIedModel("test")
+	defer model.Destroy()
+	lDevice1 := model.CreateLogicalDevice("SENSORS")
+	lln0 := lDevice1.CreateLogicalNode("LLN0")
+	lln0.CreateDataObjectCDC_SAV("FLOAT", false)
+
+	server := iec61850.NewIedServer(model)
+	defer server.Destroy()
+	server.Start(tcpPort)
+	defer server.Stop()
+
+	client := iec61850.NewIedClient()
+	err := client.Connect("localhost", tcpPort)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	objectRef := "testSENSORS/LLN0.MISSING.instMag.f"
+	_, err = client.ReadObject(objectRef, iec61850.IEC61850_FC_MX)
+	if !errors.Is(err, iec61850.ErrObjectDoesNotExist) {
+		t.Fatalf("unexpected error: %v, expect: %v", err, iec61850.ErrObjectDoesNotExist)
+	}
+
+	var clientError *iec61850.ClientError
+	if !errors.As(err, &clientError) {
+		t.Fatalf("unexpected error type: %T", err)
+	}
+	if clientError.Op != "read object" || clientError.Ref != objectRef || clientError.Code != iec61850.IED_ERROR_OBJECT_DOES_NOT_EXIST {
+		t.Errorf("unexpected client error: %+v", clientError)
+	}
+
+	var code iec61850.IedError
+	if !errors.As(err, &code) || code != iec61850.IED_ERROR_OBJECT_DOES_NOT_EXIST {
+		t.Errorf("unexpected error code: %v", code)
+	}
+}
@@ -0,0 +1,97 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/weiheng-tech/go-libiec61850/iec61850"
+	"github.com/weiheng-tech/go-libiec61850/iec61850/scl_xml"
+)
+
+func TestIEC61850ClientExplainDataSetComponents(t *testing.T) {
+	tcpPort := 10224
+
+	scl, err := scl_xml.GetSCL("test_icd.icd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dataSet, err := scl.GetDataSet("Huwor_JF204MONT/LLN0.dsAin1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// AvDsch is served as SAV, so its components are named by the type specification of the server ("instMag")
+	// instead of the MV of the SCL ("mag"). DschQ is unknown to the server and is named by the SCL.
+	model := iec61850.NewIedModel("Huwor_JF204")
+	defer model.Destroy()
+	lDevice1 := model.CreateLogicalDevice("MONT")
+	lDevice1.CreateLogicalNode("LLN0")
+	spdc := lDevice1.CreateLogicalNode("SPDC1")
+	avDsch := spdc.CreateDataObjectCDC_SAV("AvDsch", false)
+
+	server := iec61850.NewIedServer(model)
+	defer server.Destroy()
+	server.Start(tcpPort)
+	defer server.Stop()
+
+	server.LockDataModel()
+	server.UpdateFloatAttributeValue(avDsch.GetChild("instMag.f"), 1.5)
+	server.UnlockDataModel()
+
+	client := iec61850.NewIedClient()
+	defer client.Close()
+	if err := client.Connect("localhost", tcpPort); err != nil {
+		t.Fatal(err)
+	}
+
+	values := make([]iec61850.MmsValue, len(dataSet.FCDA))
+	values[0], err = client.ReadObject("Huwor_JF204MONT/SPDC1.AvDsch", iec61850.IEC61850_FC_MX)
+	if err != nil {
+		t.Fatal(err)
+	}
+	values[4] = iec61850.Structure{
+		iec61850.Structure{iec61850.Float32(2.5)},
+		iec61850.Quality(0),
+		iec61850.Timestamp{},
+	}
+
+	explained, err := client.ExplainDataSetComponents(values, dataSet)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if value := explained["Huwor_JF204MONT/SPDC1.AvDsch.instMag.f"]; value != iec61850.Float32(1.5) {
+		t.Errorf("unexpected AvDsch.instMag.f: %v", value)
+	}
+	if _, ok := explained["Huwor_JF204MONT/SPDC1.AvDsch.q"]; !ok {
+		t.Error("AvDsch.q not explained")
+	}
+	if _, ok := explained["Huwor_JF204MONT/SPDC1.AvDsch.mag.f"]; ok {
+		t.Error("AvDsch named by the SCL instead of the type specification")
+	}
+
+	if value := explained["Huwor_JF204MONT/SPDC1.DschQ.mag.f"]; value != iec61850.Float32(2.5) {
+		t.Errorf("unexpected DschQ.mag.f: %v", value)
+	}
+	if _, ok := explained["Huwor_JF204MONT/SPDC1.DschQ.q"]; !ok {
+		t.Error("DschQ.q not explained")
+	}
+	if _, ok := explained["Huwor_JF204MONT/SPDC1.DschQ.mag"]; ok {
+		t.Error("DschQ.mag mapped to its only component")
+	}
+
+	if _, err := client.ExplainDataSetComponents(values[:1], dataSet); err == nil {
+		t.Error("explained values of another data set")
+	}
+
+	// ExplainDataSetValues keeps the data attributes of the SCL without asking the IED
+	legacy, err := client.ExplainDataSetValues(values, dataSet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value := legacy["Huwor_JF204MONT/SPDC1.DschQ.mag"]; value != iec61850.Float32(2.5) {
+		t.Errorf("unexpected DschQ.mag: %v", value)
+	}
+	if _, ok := legacy["Huwor_JF204MONT/SPDC1.DschQ.mag.f"]; ok {
+		t.Error("DschQ split into its basic components")
+	}
+}
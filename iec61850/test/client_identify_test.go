@@ -0,0 +1,53 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/weiheng-tech/go-libiec61850/iec61850"
+)
+
+func TestIEC61850ClientIdentify(t *testing.T) {
+	tcpPort := 10213
+
+	model := iec61850.NewIedModel("test")
+	defer model.Destroy()
+	lDevice1 := model.CreateLogicalDevice("SENSORS")
+	lln0 := lDevice1.CreateLogicalNode("LLN0")
+	lln0.CreateDataObjectCDC_SAV("FLOAT", false)
+
+	server := iec61850.NewIedServer(model)
+	defer server.Destroy()
+	server.Start(tcpPort)
+	defer server.Stop()
+
+	client := iec61850.NewIedClient()
+	defer client.Close()
+
+	if _, err := client.Negotiated(); err == nil {
+		t.Error("negotiated parameters without connection")
+	}
+
+	err := client.Connect("localhost", tcpPort)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	identity, err := client.Identify()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if identity.VendorName == "" || identity.ModelName == "" {
+		t.Errorf("unexpected identity: %+v", identity)
+	}
+
+	negotiated, err := client.Negotiated()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if negotiated.MaxPduSize <= 0 || negotiated.DataStructureNestingLevel <= 0 {
+		t.Errorf("unexpected negotiated parameters: %+v", negotiated)
+	}
+	if !negotiated.ServicesSupported.Bit(iec61850.MMS_SERVICE_READ) || !negotiated.ServicesSupported.Bit(iec61850.MMS_SERVICE_IDENTIFY) {
+		t.Errorf("read and identify not supported: %x", negotiated.ServicesSupported.Bits)
+	}
+}
@@ -0,0 +1,140 @@
+package test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/weiheng-tech/go-libiec61850/iec61850"
+)
+
+func TestIEC61850ManagedClient(t *testing.T) {
+	tcpPort := 10208
+
+	model := iec61850.NewIedModel("test")
+	defer model.Destroy()
+	lDevice1 := model.CreateLogicalDevice("SENSORS")
+	lln0 := lDevice1.CreateLogicalNode("LLN0")
+	dataF := lln0.CreateDataObjectCDC_SAV("FLOAT", false)
+	dataset := lln0.CreateDataSet("EVENTS")
+	dataset.AddDataSetEntry("LLN0$MX$FLOAT$instMag$f")
+	lln0.CreateReportControlBlock("urcb01", "events", false, "",
+		iec61850.TRG_OPT_DATA_CHANGED, iec61850.RPT_OPT_SEQ_NUM, 0, 0)
+
+	server := iec61850.NewIedServer(model)
+	defer server.Destroy()
+
+	managed := iec61850.NewManagedClient("localhost", tcpPort, iec61850.ReconnectBackoff(iec61850.Backoff{
+		Initial:    50 * time.Millisecond,
+		Max:        200 * time.Millisecond,
+		Multiplier: 2,
+		Jitter:     0.2,
+	}))
+	defer managed.Close()
+
+	restored := make(chan struct{}, 10)
+	managed.CreateDataSetOnConnect("@managed", []iec61850.FCDA{
+		{Ref: "testSENSORS/LLN0.FLOAT.instMag.f", FC: iec61850.IEC61850_FC_MX},
+	})
+	reports := make(chan iec61850.Report, 8)
+	managed.EnableReportOnConnect("testSENSORS/LLN0.RP.urcb01", "events", "testSENSORS/LLN0$EVENTS", func(report iec61850.Report) {
+		reports <- report
+	})
+	managed.OnConnect(func(client *iec61850.IedClient) error {
+		restored <- struct{}{}
+		return nil
+	})
+
+	// no server yet, fail fast
+	err := managed.Do(func(client *iec61850.IedClient) error {
+		return nil
+	})
+	if !errors.Is(err, iec61850.ErrNotConnected) {
+		t.Fatalf("unexpected error: %v, expect: %v", err, iec61850.ErrNotConnected)
+	}
+
+	server.Start(tcpPort)
+	defer server.Stop()
+
+	server.LockDataModel()
+	server.UpdateFloatAttributeValue(dataF.GetChild("instMag.f"), 2.5)
+	server.UnlockDataModel()
+
+	select {
+	case <-restored:
+	case <-time.After(5 * time.Second):
+		t.Fatal("managed client did not connect")
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !managed.Connected() && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	var value iec61850.MmsValue
+	err = managed.Do(func(client *iec61850.IedClient) (err error) {
+		value, err = client.ReadObject("testSENSORS/LLN0.FLOAT.instMag.f", iec61850.IEC61850_FC_MX)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != iec61850.Float32(2.5) {
+		t.Errorf("unexpected value: %v", value)
+	}
+
+	// the server goes away, Do fails fast until the connection is restored
+	server.Stop()
+
+	deadline = time.Now().Add(5 * time.Second)
+	for managed.Connected() && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	err = managed.Do(func(client *iec61850.IedClient) error {
+		return nil
+	})
+	if !errors.Is(err, iec61850.ErrNotConnected) {
+		t.Fatalf("unexpected error: %v, expect: %v", err, iec61850.ErrNotConnected)
+	}
+
+	server.Start(tcpPort)
+
+	select {
+	case <-restored:
+	case <-time.After(5 * time.Second):
+		t.Fatal("managed client did not reconnect")
+	}
+
+	deadline = time.Now().Add(5 * time.Second)
+	for !managed.Connected() && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// the association specific data set of the lost connection is created again
+	var values []iec61850.MmsValue
+	err = managed.Do(func(client *iec61850.IedClient) (err error) {
+		values, err = client.ReadDataSetValues("@managed", "")
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(values) != 1 || values[0] != iec61850.Float32(2.5) {
+		t.Errorf("unexpected values of @managed: %v", values)
+	}
+
+	// the report handler is installed again before the report control block is enabled
+	for len(reports) > 0 {
+		<-reports
+	}
+
+	server.LockDataModel()
+	server.UpdateFloatAttributeValue(dataF.GetChild("instMag.f"), 3.5)
+	server.UnlockDataModel()
+
+	report := receiveReport(t, reports)
+	if report.RptID != "events" || len(report.Values) != 1 || report.Values[0] != iec61850.Float32(3.5) {
+		t.Errorf("unexpected report: %+v", report)
+	}
+}
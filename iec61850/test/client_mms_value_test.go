@@ -0,0 +1,188 @@
+package test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/weiheng-tech/go-libiec61850/iec61850"
+)
+
+func TestIEC61850ClientReadMmsValues(t *testing.T) {
+	tcpPort := 10201
+
+	model := iec61850.NewIedModel("test")
+	defer model.Destroy()
+	lDevice1 := model.CreateLogicalDevice("SENSORS")
+	lln0 := lDevice1.CreateLogicalNode("LLN0")
+	dataVSS := lln0.CreateDataObjectCDC_VSS("DATA")
+	dataF := lln0.CreateDataObjectCDC_SAV("FLOAT", false)
+	dataset := lln0.CreateDataSet("DATASET")
+	dataset.AddDataSetEntry("LLN0$ST$DATA$stVal")
+	dataset.AddDataSetEntry("LLN0$MX$FLOAT")
+
+	server := iec61850.NewIedServer(model)
+	defer server.Destroy()
+	server.Start(tcpPort)
+	defer server.Stop()
+
+	server.LockDataModel()
+	server.UpdateVisibleStringAttributeValue(dataVSS.GetChild("stVal"), "Hello World!")
+	server.UpdateFloatAttributeValue(dataF.GetChild("instMag.f"), 3.5)
+	server.UpdateUTCTimeAttributeValue(dataF.GetChild("t"), 1700000000123)
+	server.UnlockDataModel()
+
+	client := iec61850.NewIedClient()
+	err := client.Connect("localhost", tcpPort)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	value, err := client.ReadObject("testSENSORS/LLN0.FLOAT.instMag.f", iec61850.IEC61850_FC_MX)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != iec61850.Float32(3.5) {
+		t.Errorf("unexpected instMag.f: %#v", value)
+	}
+
+	q, err := client.ReadObject("testSENSORS/LLN0.FLOAT.q", iec61850.IEC61850_FC_MX)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if quality, ok := q.(iec61850.Quality); !ok || !quality.IsGood() || quality.BitSize() != 13 {
+		t.Errorf("unexpected q: %#v", q)
+	}
+
+	values, err := client.ReadDataSetValues("testSENSORS/LLN0.DATASET", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(values) != 2 {
+		t.Fatalf("unexpected dataset size: %d", len(values))
+	}
+	if values[0] != iec61850.VisibleString("Hello World!") {
+		t.Errorf("unexpected stVal: %#v", values[0])
+	}
+
+	sav, ok := values[1].(iec61850.Structure)
+	if !ok {
+		t.Fatalf("unexpected FLOAT: %#v", values[1])
+	}
+	for _, v := range sav {
+		switch rv := v.(type) {
+		case iec61850.Quality:
+			t.Errorf("q of a structure decoded without its name: %v", rv)
+		case iec61850.BitString:
+			// q, named only by the type specification
+			if rv.Size != 13 {
+				t.Errorf("unexpected bit string: %+v", rv)
+			}
+		case iec61850.Timestamp:
+			if rv.Time().UnixMilli() != 1700000000123 {
+				t.Errorf("unexpected timestamp: %v", rv.Time())
+			}
+		}
+	}
+	fmt.Printf("Dataset = %v\n", values)
+}
+
+func TestIEC61850ClientReadArray(t *testing.T) {
+	client := iec61850.NewIedClient()
+	err := client.Connect("localhost", 102)
+	if err != nil {
+		fmt.Println("Error connecting:", err)
+		return
+	}
+	defer client.Close()
+
+	value, err := client.ReadObject("METER1/MHAI1.HA.phsAHar", iec61850.IEC61850_FC_MX)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	har, ok := value.(iec61850.Array)
+	if !ok {
+		t.Fatalf("unexpected phsAHar: %#v", value)
+	}
+	if har.ElementType != iec61850.MMS_STRUCTURE {
+		t.Errorf("unexpected element type: %d", har.ElementType)
+	}
+	for i, element := range har.Elements {
+		fmt.Printf("phsAHar(%d) = %v\n", i, element)
+	}
+}
+
+func TestIEC61850WriteUnsupportedMmsType(t *testing.T) {
+	client := iec61850.NewIedClient()
+	defer client.Close()
+
+	for _, value := range []iec61850.MmsValue{
+		iec61850.GeneralizedTime("20240101000000Z"),
+		iec61850.BCD("1234"),
+		iec61850.ObjectID("1.0.9506.2.1"),
+		iec61850.Structure{iec61850.Boolean(true), iec61850.BCD("1")},
+	} {
+		err := client.WriteObject("test/LLN0.DATA.stVal", iec61850.IEC61850_FC_ST, value)
+
+		var typeError *iec61850.MmsTypeError
+		if !errors.As(err, &typeError) || !errors.Is(err, iec61850.ErrTypeUnsupported) {
+			t.Errorf("expect MmsTypeError for %#v, got %v", value, err)
+		}
+	}
+}
+
+func TestIEC61850ClientReadArrayOfStructures(t *testing.T) {
+	tcpPort := 10221
+
+	model := iec61850.NewIedModel("test")
+	defer model.Destroy()
+	lDevice1 := model.CreateLogicalDevice("METER")
+	lDevice1.CreateLogicalNode("LLN0")
+	mhai := lDevice1.CreateLogicalNode("MHAI1")
+	ha := mhai.CreateDataObject("HA")
+	har := ha.CreateDataAttribute("har", iec61850.IEC61850_CONSTRUCTED, iec61850.IEC61850_FC_MX, 0, 4)
+	har.CreateDataAttribute("f", iec61850.IEC61850_FLOAT32, iec61850.IEC61850_FC_MX, 0, 0)
+	har.CreateDataAttribute("v", iec61850.IEC61850_INT32, iec61850.IEC61850_FC_MX, 0, 3)
+
+	server := iec61850.NewIedServer(model)
+	defer server.Destroy()
+	server.Start(tcpPort)
+	defer server.Stop()
+
+	client := iec61850.NewIedClient()
+	defer client.Close()
+	if err := client.Connect("localhost", tcpPort); err != nil {
+		t.Fatal(err)
+	}
+
+	value, err := client.ReadObject("testMETER/MHAI1.HA.har", iec61850.IEC61850_FC_MX)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	array, ok := value.(iec61850.Array)
+	if !ok {
+		t.Fatalf("expect Array, got %#v", value)
+	}
+	if array.ElementType != iec61850.MMS_STRUCTURE || len(array.Elements) != 4 {
+		t.Fatalf("expect 4 structures, got %v %#v", array.ElementType, array.Elements)
+	}
+
+	for i, element := range array.Elements {
+		structure, ok := element.(iec61850.Structure)
+		if !ok || len(structure) != 2 {
+			t.Fatalf("element %d: expect structure of 2 members, got %#v", i, element)
+		}
+		if _, ok := structure[0].(iec61850.Float32); !ok {
+			t.Errorf("element %d: expect Float32, got %#v", i, structure[0])
+		}
+
+		nested, ok := structure[1].(iec61850.Array)
+		if !ok || nested.ElementType != iec61850.MMS_INTEGER || len(nested.Elements) != 3 {
+			t.Errorf("element %d: expect array of 3 integers, got %#v", i, structure[1])
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/weiheng-tech/go-libiec61850/iec61850"
+)
+
+func TestIEC61850ClientReadMany(t *testing.T) {
+	tcpPort := 10203
+
+	model := iec61850.NewIedModel("test")
+	defer model.Destroy()
+	lDevice1 := model.CreateLogicalDevice("SENSORS")
+	lln0 := lDevice1.CreateLogicalNode("LLN0")
+	dataVSS := lln0.CreateDataObjectCDC_VSS("DATA")
+	dataF := lln0.CreateDataObjectCDC_SAV("FLOAT", false)
+	dataI := lln0.CreateDataObjectCDC_SAV("INT", true)
+
+	server := iec61850.NewIedServer(model)
+	defer server.Destroy()
+	server.Start(tcpPort)
+	defer server.Stop()
+
+	server.LockDataModel()
+	server.UpdateVisibleStringAttributeValue(dataVSS.GetChild("stVal"), "Hello World!")
+	server.UpdateFloatAttributeValue(dataF.GetChild("instMag.f"), 3.5)
+	server.UpdateInt32AttributeValue(dataI.GetChild("instMag.i"), 42)
+	server.UnlockDataModel()
+
+	client := iec61850.NewIedClient(iec61850.MaxVariablesPerRead(2))
+	err := client.Connect("localhost", tcpPort)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	results := client.ReadMany([]iec61850.ObjectRef{
+		{Ref: "testSENSORS/LLN0.FLOAT.instMag.f", FC: iec61850.IEC61850_FC_MX},
+		{Ref: "testSENSORS/LLN0.DATA.stVal", FC: iec61850.IEC61850_FC_ST},
+		{Ref: "testSENSORS/LLN0.NONE.stVal", FC: iec61850.IEC61850_FC_ST},
+		{Ref: "testSENSORS/LLN0.INT.instMag.i", FC: iec61850.IEC61850_FC_MX},
+		{Ref: "invalid", FC: iec61850.IEC61850_FC_MX},
+	})
+
+	if len(results) != 5 {
+		t.Fatalf("unexpected result size: %d", len(results))
+	}
+	if results[0].Err != nil || results[0].Value != iec61850.Float32(3.5) {
+		t.Errorf("unexpected result: %+v", results[0])
+	}
+	if results[1].Err != nil || results[1].Value != iec61850.VisibleString("Hello World!") {
+		t.Errorf("unexpected result: %+v", results[1])
+	}
+	if results[2].Err == nil {
+		t.Errorf("expect error for %s", results[2].Ref)
+	}
+	if results[3].Err != nil || results[3].Value != iec61850.Integer(42) {
+		t.Errorf("unexpected result: %+v", results[3])
+	}
+	if results[4].Err == nil {
+		t.Errorf("expect error for %s", results[4].Ref)
+	}
+}
@@ -0,0 +1,153 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/weiheng-tech/go-libiec61850/iec61850"
+)
+
+type floatSample struct {
+	Mag float32          `iec61850:"instMag.f"`
+	Q   iec61850.Quality `iec61850:"q"`
+	T   time.Time        `iec61850:"t"`
+}
+
+func TestIEC61850ClientReadInto(t *testing.T) {
+	tcpPort := 10202
+
+	model := iec61850.NewIedModel("test")
+	defer model.Destroy()
+	lDevice1 := model.CreateLogicalDevice("SENSORS")
+	lln0 := lDevice1.CreateLogicalNode("LLN0")
+	dataF := lln0.CreateDataObjectCDC_SAV("FLOAT", false)
+
+	server := iec61850.NewIedServer(model)
+	defer server.Destroy()
+	server.Start(tcpPort)
+	defer server.Stop()
+
+	server.LockDataModel()
+	server.UpdateFloatAttributeValue(dataF.GetChild("instMag.f"), 42.5)
+	server.UpdateUTCTimeAttributeValue(dataF.GetChild("t"), 1700000000000)
+	server.UnlockDataModel()
+
+	client := iec61850.NewIedClient()
+	err := client.Connect("localhost", tcpPort)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	var sample floatSample
+	err = client.ReadInto("testSENSORS/LLN0.FLOAT", iec61850.IEC61850_FC_MX, &sample)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if sample.Mag != 42.5 {
+		t.Errorf("unexpected mag: %v", sample.Mag)
+	}
+	if !sample.Q.IsGood() {
+		t.Errorf("unexpected quality: %v", sample.Q)
+	}
+	if sample.T.Unix() != 1700000000 {
+		t.Errorf("unexpected time: %v", sample.T)
+	}
+
+	var missing struct {
+		Ang float32 `iec61850:"ang.f"`
+	}
+	err = client.ReadInto("testSENSORS/LLN0.FLOAT", iec61850.IEC61850_FC_MX, &missing)
+	if err == nil {
+		t.Error("expect error for missing component")
+	}
+}
+
+type settings struct {
+	Scale   float32 `iec61850:"scale"`
+	Enabled bool    `iec61850:"enabled"`
+	Range   struct {
+		Max int32 `iec61850:"max"`
+	} `iec61850:"range"`
+}
+
+type allSettings struct {
+	Scale   float32 `iec61850:"scale"`
+	Enabled bool    `iec61850:"enabled"`
+	Max     int32   `iec61850:"range.max"`
+	Offset  float32 `iec61850:"offset"`
+	Min     int32   `iec61850:"range.min"`
+}
+
+func TestIEC61850ClientWriteFrom(t *testing.T) {
+	tcpPort := 10223
+
+	model := iec61850.NewIedModel("test")
+	defer model.Destroy()
+	lDevice1 := model.CreateLogicalDevice("SENSORS")
+	lln0 := lDevice1.CreateLogicalNode("LLN0")
+	cfg := lln0.CreateDataObject("Cfg")
+	cfg.CreateDataAttribute("scale", iec61850.IEC61850_FLOAT32, iec61850.IEC61850_FC_CF, 0, 0)
+	offset := cfg.CreateDataAttribute("offset", iec61850.IEC61850_FLOAT32, iec61850.IEC61850_FC_CF, 0, 0)
+	cfg.CreateDataAttribute("enabled", iec61850.IEC61850_BOOLEAN, iec61850.IEC61850_FC_CF, 0, 0)
+	valueRange := cfg.CreateDataAttribute("range", iec61850.IEC61850_CONSTRUCTED, iec61850.IEC61850_FC_CF, 0, 0)
+	valueRange.CreateDataAttribute("min", iec61850.IEC61850_INT32, iec61850.IEC61850_FC_CF, 0, 0)
+	valueRange.CreateDataAttribute("max", iec61850.IEC61850_INT32, iec61850.IEC61850_FC_CF, 0, 0)
+
+	server := iec61850.NewIedServer(model)
+	defer server.Destroy()
+	server.Start(tcpPort)
+	defer server.Stop()
+
+	server.LockDataModel()
+	server.UpdateFloatAttributeValue(offset, 1.5)
+	server.UnlockDataModel()
+
+	client := iec61850.NewIedClient()
+	defer client.Close()
+	if err := client.Connect("localhost", tcpPort); err != nil {
+		t.Fatal(err)
+	}
+
+	// components without a tagged field are written concurrently by another client
+	other := iec61850.NewIedClient()
+	defer other.Close()
+	if err := other.Connect("localhost", tcpPort); err != nil {
+		t.Fatal(err)
+	}
+	if err := other.WriteInt32("testSENSORS/LLN0.Cfg.range.min", iec61850.IEC61850_FC_CF, -5); err != nil {
+		t.Fatal(err)
+	}
+
+	written := settings{Scale: 2.5, Enabled: true}
+	written.Range.Max = 100
+	if err := client.WriteFrom("testSENSORS/LLN0.Cfg", iec61850.IEC61850_FC_CF, &written); err != nil {
+		t.Fatal(err)
+	}
+
+	var read allSettings
+	if err := client.ReadInto("testSENSORS/LLN0.Cfg", iec61850.IEC61850_FC_CF, &read); err != nil {
+		t.Fatal(err)
+	}
+	if read.Scale != written.Scale || read.Enabled != written.Enabled || read.Max != written.Range.Max {
+		t.Errorf("expect %+v, got %+v", written, read)
+	}
+	if read.Offset != 1.5 || read.Min != -5 {
+		t.Errorf("untagged components changed: offset %v, min %v", read.Offset, read.Min)
+	}
+
+	var missing struct {
+		Gain float32 `iec61850:"gain"`
+	}
+	if err := client.WriteFrom("testSENSORS/LLN0.Cfg", iec61850.IEC61850_FC_CF, missing); err == nil {
+		t.Error("expect error for missing component")
+	}
+
+	var element struct {
+		Har float32 `iec61850:"har(0).f"`
+	}
+	if err := client.WriteFrom("testSENSORS/LLN0.Cfg", iec61850.IEC61850_FC_CF, element); err == nil {
+		t.Error("expect error for array element")
+	}
+}
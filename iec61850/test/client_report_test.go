@@ -0,0 +1,106 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/weiheng-tech/go-libiec61850/iec61850"
+)
+
+func TestIEC61850ClientReport(t *testing.T) {
+	tcpPort := 10217
+
+	model := iec61850.NewIedModel("test")
+	defer model.Destroy()
+	lDevice1 := model.CreateLogicalDevice("SENSORS")
+	lln0 := lDevice1.CreateLogicalNode("LLN0")
+	dataF := lln0.CreateDataObjectCDC_SAV("FLOAT", false)
+	lln0.CreateDataObjectCDC_SAV("INT", true)
+	dataset := lln0.CreateDataSet("EVENTS")
+	dataset.AddDataSetEntry("LLN0$MX$FLOAT$instMag$f")
+	dataset.AddDataSetEntry("LLN0$MX$INT$instMag$i")
+	lln0.CreateReportControlBlock("urcb01", "events", false, "EVENTS",
+		iec61850.TRG_OPT_DATA_CHANGED|iec61850.TRG_OPT_GI, iec61850.RPT_OPT_SEQ_NUM, 0, 0)
+
+	server := iec61850.NewIedServer(model)
+	defer server.Destroy()
+	server.Start(tcpPort)
+	defer server.Stop()
+
+	client := iec61850.NewIedClient()
+	defer client.Close()
+
+	err := client.Connect("localhost", tcpPort)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rcbRef := "testSENSORS/LLN0.RP.urcb01"
+
+	rcb, err := client.GetRCBValues(rcbRef)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rcb.Buffered || rcb.RptEna || rcb.RptID != "events" || rcb.DatSet != "testSENSORS/LLN0$EVENTS" {
+		t.Errorf("unexpected report control block: %+v", rcb)
+	}
+
+	reports := make(chan iec61850.Report, 8)
+	err = client.InstallReportHandler(rcbRef, "events", func(report iec61850.Report) {
+		reports <- report
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rcb.RptEna = true
+	rcb.OptFlds = iec61850.RPT_OPT_SEQ_NUM | iec61850.RPT_OPT_TIME_STAMP | iec61850.RPT_OPT_REASON_FOR_INCLUSION | iec61850.RPT_OPT_DATA_SET
+	err = client.SetRCBValues(rcb, iec61850.RCB_ELEMENT_OPT_FLDS|iec61850.RCB_ELEMENT_RPT_ENA, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server.LockDataModel()
+	server.UpdateFloatAttributeValue(dataF.GetChild("instMag.f"), 1.5)
+	server.UnlockDataModel()
+
+	report := receiveReport(t, reports)
+	if report.RptID != "events" || !report.HasSeqNum || report.Timestamp.IsZero() || report.DataSetName != "testSENSORS/LLN0$EVENTS" {
+		t.Errorf("unexpected report: %+v", report)
+	}
+	if len(report.Values) != 2 || report.Reasons[0] != iec61850.REASON_DATA_CHANGE || report.Values[0] != iec61850.Float32(1.5) {
+		t.Fatalf("unexpected report values: %+v", report)
+	}
+	if report.Included(1) || report.Values[1] != nil {
+		t.Errorf("member 1 not expected in report: %+v", report)
+	}
+
+	rcb.GI = true
+	err = client.SetRCBValues(rcb, iec61850.RCB_ELEMENT_GI, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report = receiveReport(t, reports)
+	for i, reason := range report.Reasons {
+		if !reason.Has(iec61850.REASON_GI) || !report.Included(i) {
+			t.Errorf("member %d: expect reason GI, got %v", i, reason)
+		}
+	}
+
+	if err := client.UninstallReportHandler(rcbRef); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func receiveReport(t *testing.T, reports <-chan iec61850.Report) iec61850.Report {
+	t.Helper()
+
+	select {
+	case report := <-reports:
+		return report
+	case <-time.After(5 * time.Second):
+		t.Fatal("no report received")
+	}
+	return iec61850.Report{}
+}
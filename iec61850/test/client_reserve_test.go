@@ -0,0 +1,77 @@
+package test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/weiheng-tech/go-libiec61850/iec61850"
+)
+
+func TestIEC61850ClientReserveRCB(t *testing.T) {
+	tcpPort := 10219
+
+	model := iec61850.NewIedModel("test")
+	defer model.Destroy()
+	lDevice1 := model.CreateLogicalDevice("SENSORS")
+	lln0 := lDevice1.CreateLogicalNode("LLN0")
+	lln0.CreateDataObjectCDC_SAV("FLOAT", false)
+	dataset := lln0.CreateDataSet("EVENTS")
+	dataset.AddDataSetEntry("LLN0$MX$FLOAT$instMag$f")
+	lln0.CreateReportControlBlock("urcbA01", "", false, "", iec61850.TRG_OPT_DATA_CHANGED, iec61850.RPT_OPT_SEQ_NUM, 0, 0)
+	lln0.CreateReportControlBlock("urcbA02", "", false, "", iec61850.TRG_OPT_DATA_CHANGED, iec61850.RPT_OPT_SEQ_NUM, 0, 0)
+
+	server := iec61850.NewIedServer(model)
+	defer server.Destroy()
+	server.Start(tcpPort)
+	defer server.Stop()
+
+	clients := make([]*iec61850.IedClient, 3)
+	for i := range clients {
+		clients[i] = iec61850.NewIedClient()
+		defer clients[i].Close()
+		if err := clients[i].Connect("localhost", tcpPort); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	references, err := clients[0].ReportControlBlocks("testSENSORS/LLN0", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(references) != 2 {
+		t.Fatalf("expect 2 report control blocks, got %v", references)
+	}
+
+	first, err := clients[0].ReserveRCB("testSENSORS/LLN0", false, "testSENSORS/LLN0$EVENTS")
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := clients[1].ReserveRCB("testSENSORS/LLN0", false, "testSENSORS/LLN0$EVENTS")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Values.Ref == second.Values.Ref {
+		t.Fatalf("both clients reserved %s", first.Values.Ref)
+	}
+	defer second.Close()
+
+	if _, err := clients[2].ReserveRCB("testSENSORS/LLN0", false, "testSENSORS/LLN0$EVENTS"); !errors.Is(err, iec61850.ErrTemporarilyUnavailable) {
+		t.Errorf("expect ErrTemporarilyUnavailable, got %v", err)
+	}
+
+	if err := first.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := first.Close(); err != nil {
+		t.Errorf("second Close: %v", err)
+	}
+
+	third, err := clients[2].ReserveRCB("testSENSORS/LLN0", false, "testSENSORS/LLN0$EVENTS")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer third.Close()
+	if third.Values.Ref != first.Values.Ref {
+		t.Errorf("expect released %s, got %s", first.Values.Ref, third.Values.Ref)
+	}
+}
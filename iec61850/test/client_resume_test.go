@@ -0,0 +1,124 @@
+package test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/weiheng-tech/go-libiec61850/iec61850"
+)
+
+func TestIEC61850ClientResumeBufferedReport(t *testing.T) {
+	tcpPort := 10218
+
+	model := iec61850.NewIedModel("test")
+	defer model.Destroy()
+	lDevice1 := model.CreateLogicalDevice("SENSORS")
+	lln0 := lDevice1.CreateLogicalNode("LLN0")
+	dataF := lln0.CreateDataObjectCDC_SAV("FLOAT", false)
+	dataset := lln0.CreateDataSet("EVENTS")
+	dataset.AddDataSetEntry("LLN0$MX$FLOAT$instMag$f")
+	lln0.CreateReportControlBlock("brcb01", "events", true, "EVENTS", iec61850.TRG_OPT_DATA_CHANGED, iec61850.RPT_OPT_SEQ_NUM, 0, 0)
+
+	server := iec61850.NewIedServer(model)
+	defer server.Destroy()
+	server.Start(tcpPort)
+	defer server.Stop()
+
+	update := func(value float32) {
+		server.LockDataModel()
+		server.UpdateFloatAttributeValue(dataF.GetChild("instMag.f"), value)
+		server.UnlockDataModel()
+	}
+
+	rcbRef := "testSENSORS/LLN0.BR.brcb01"
+	store := iec61850.NewFileEntryIDStore(t.TempDir())
+	reports := make(chan iec61850.Report, 8)
+	gaps := make(chan iec61850.ReportGap, 8)
+	handler := func(report iec61850.Report) { reports <- report }
+	onGap := iec61850.OnReportGap(func(gap iec61850.ReportGap) { gaps <- gap })
+
+	client := iec61850.NewIedClient()
+	if err := client.Connect("localhost", tcpPort); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.ResumeBufferedReport(rcbRef, handler, iec61850.ResumeStore(store), onGap); err != nil {
+		t.Fatal(err)
+	}
+
+	update(1)
+	report := receiveReport(t, reports)
+	if report.EntryID == nil || report.Values[0] != iec61850.Float32(1) {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+
+	// wait for the EntryID to be stored after the handler returned
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		entryID, err := store.Load(rcbRef)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bytes.Equal(entryID, report.EntryID) {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("EntryID not stored, got %x", entryID)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	client.Close()
+
+	// buffered by the IED while no client is connected
+	update(2)
+	update(3)
+
+	client = iec61850.NewIedClient()
+	defer client.Close()
+	if err := client.Connect("localhost", tcpPort); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.ResumeBufferedReport(rcbRef, handler, iec61850.ResumeStore(store), onGap); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, expected := range []iec61850.Float32{2, 3} {
+		report := receiveReport(t, reports)
+		if report.Values[0] != expected {
+			t.Errorf("expect resumed report with %v, got %+v", expected, report)
+		}
+	}
+
+	select {
+	case gap := <-gaps:
+		t.Errorf("unexpected gap: %+v", gap)
+	default:
+	}
+
+	if err := store.Store(rcbRef, []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.ResumeBufferedReport(rcbRef, handler, iec61850.ResumeStore(store), onGap); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case gap := <-gaps:
+		if gap.Cause != iec61850.GAP_CAUSE_ENTRY_NOT_FOUND || gap.Err == nil {
+			t.Errorf("unexpected gap: %+v", gap)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("unknown EntryID not reported as gap")
+	}
+}
+
+func TestIEC61850ClientResumeBufferedReportWithoutStore(t *testing.T) {
+	client := iec61850.NewIedClient()
+	defer client.Close()
+
+	err := client.ResumeBufferedReport("testSENSORS/LLN0.BR.brcb01", func(iec61850.Report) {})
+	if !errors.Is(err, iec61850.ErrNotConnected) {
+		t.Errorf("expect ErrNotConnected without a connection and a store, got %v", err)
+	}
+}
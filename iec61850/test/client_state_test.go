@@ -0,0 +1,61 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/weiheng-tech/go-libiec61850/iec61850"
+)
+
+func TestIEC61850ClientStateChanges(t *testing.T) {
+	tcpPort := 10207
+
+	model := iec61850.NewIedModel("test")
+	defer model.Destroy()
+	lDevice1 := model.CreateLogicalDevice("SENSORS")
+	lln0 := lDevice1.CreateLogicalNode("LLN0")
+	lln0.CreateDataObjectCDC_SAV("FLOAT", false)
+
+	server := iec61850.NewIedServer(model)
+	defer server.Destroy()
+	server.Start(tcpPort)
+
+	client := iec61850.NewIedClient()
+	changes := client.StateChanges()
+
+	err := client.Connect("localhost", tcpPort)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectStateChange(t, changes, iec61850.IED_STATE_CONNECTING, iec61850.STATE_CAUSE_CONNECT)
+	expectStateChange(t, changes, iec61850.IED_STATE_CONNECTED, iec61850.STATE_CAUSE_CONNECT)
+
+	// the server closes the connection
+	server.Stop()
+	expectStateChange(t, changes, iec61850.IED_STATE_CLOSED, iec61850.STATE_CAUSE_CONNECTION_LOST)
+
+	client.Close()
+	for range changes {
+	}
+}
+
+func expectStateChange(t *testing.T, changes <-chan iec61850.StateChange, state iec61850.ClientState, cause iec61850.StateChangeCause) {
+	t.Helper()
+
+	for {
+		select {
+		case change := <-changes:
+			// a connection may pass through closing before it is closed
+			if change.State == iec61850.IED_STATE_CLOSING && state == iec61850.IED_STATE_CLOSED {
+				continue
+			}
+			if change.State != state || change.Cause != cause {
+				t.Errorf("unexpected state change: %v (%v), expect: %v (%v)", change.State, change.Cause, state, cause)
+			}
+			return
+		case <-time.After(5 * time.Second):
+			t.Fatalf("no state change to %v received", state)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+//go:build tls
+
+package test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/weiheng-tech/go-libiec61850/iec61850"
+)
+
+func TestIEC61850NilTLSConfig(t *testing.T) {
+	model := iec61850.NewIedModel("test")
+	defer model.Destroy()
+	model.CreateLogicalDevice("SENSORS").CreateLogicalNode("LLN0")
+
+	if _, err := iec61850.NewIedClientWithTLS(nil); err == nil {
+		t.Error("created client without TLSConfig")
+	}
+	if _, err := iec61850.NewIedServerWithTLS(model, nil); err == nil {
+		t.Error("created server without TLSConfig")
+	}
+}
+
+func TestIEC61850ClientTLS(t *testing.T) {
+	tcpPort := 10210
+
+	caCert, caKey := newTestCertificate(t, "test ca", nil, nil)
+	serverCert, serverKey := newTestCertificate(t, "test server", caCert, caKey)
+	clientCert, clientKey := newTestCertificate(t, "test client", caCert, caKey)
+
+	model := iec61850.NewIedModel("test")
+	defer model.Destroy()
+	lDevice1 := model.CreateLogicalDevice("SENSORS")
+	lln0 := lDevice1.CreateLogicalNode("LLN0")
+	dataF := lln0.CreateDataObjectCDC_SAV("FLOAT", false)
+
+	server, err := iec61850.NewIedServerWithTLS(model, &iec61850.TLSConfig{
+		OwnCertificate:      pemCertificate(serverCert),
+		OwnKey:              pemKey(t, serverKey),
+		CACertificates:      [][]byte{pemCertificate(caCert)},
+		AllowedCertificates: [][]byte{pemCertificate(clientCert)},
+		MinVersion:          iec61850.TLS_VERSION_TLS_1_2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Destroy()
+	server.Start(tcpPort)
+	defer server.Stop()
+
+	server.LockDataModel()
+	server.UpdateFloatAttributeValue(dataF.GetChild("instMag.f"), 4.5)
+	server.UnlockDataModel()
+
+	client, err := iec61850.NewIedClientWithTLS(&iec61850.TLSConfig{
+		OwnCertificate:    pemCertificate(clientCert),
+		OwnKey:            pemKey(t, clientKey),
+		CACertificates:    [][]byte{pemCertificate(caCert)},
+		MinVersion:        iec61850.TLS_VERSION_TLS_1_2,
+		RenegotiationTime: time.Minute,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	err = client.Connect("localhost", tcpPort)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	value, err := client.ReadObject("testSENSORS/LLN0.FLOAT.instMag.f", iec61850.IEC61850_FC_MX)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != iec61850.Float32(4.5) {
+		t.Errorf("unexpected value: %v", value)
+	}
+
+	// a certificate of the CA which is not in the allowed certificates of the server
+	otherCert, otherKey := newTestCertificate(t, "other client", caCert, caKey)
+	other, err := iec61850.NewIedClientWithTLS(&iec61850.TLSConfig{
+		OwnCertificate: pemCertificate(otherCert),
+		OwnKey:         pemKey(t, otherKey),
+		CACertificates: [][]byte{pemCertificate(caCert)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer other.Close()
+
+	if err := other.Connect("localhost", tcpPort); err == nil {
+		t.Error("connection with a certificate which is not allowed succeeded")
+	}
+}
+
+// newTestCertificate create a certificate signed by parent, a self-signed CA certificate if parent is nil
+func newTestCertificate(t *testing.T, name string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage |= x509.KeyUsageCertSign
+		parent, parentKey = template, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return certificate, key
+}
+
+func pemCertificate(certificate *x509.Certificate) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate.Raw})
+}
+
+func pemKey(t *testing.T, key *ecdsa.PrivateKey) []byte {
+	t.Helper()
+
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+}
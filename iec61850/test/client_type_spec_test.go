@@ -0,0 +1,67 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/weiheng-tech/go-libiec61850/iec61850"
+)
+
+func TestIEC61850ClientGetTypeSpec(t *testing.T) {
+	tcpPort := 10214
+
+	model := iec61850.NewIedModel("test")
+	defer model.Destroy()
+	lDevice1 := model.CreateLogicalDevice("SENSORS")
+	lln0 := lDevice1.CreateLogicalNode("LLN0")
+	lln0.CreateDataObjectCDC_SAV("FLOAT", false)
+
+	server := iec61850.NewIedServer(model)
+	defer server.Destroy()
+	server.Start(tcpPort)
+	defer server.Stop()
+
+	client := iec61850.NewIedClient()
+	defer client.Close()
+
+	err := client.Connect("localhost", tcpPort)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	spec, err := client.GetTypeSpec("testSENSORS/LLN0.FLOAT", iec61850.IEC61850_FC_MX)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if spec.Type != iec61850.MMS_STRUCTURE || spec.ElementCount != len(spec.Children) {
+		t.Fatalf("unexpected specification: %+v", spec)
+	}
+
+	instMag := spec.Child("instMag")
+	if instMag == nil || instMag.Child("f") == nil {
+		t.Fatalf("instMag.f not found: %+v", spec)
+	}
+	if f := instMag.Child("f"); f.Type != iec61850.MMS_FLOAT || f.FloatWidth != 32 || f.ExponentWidth != 8 {
+		t.Errorf("unexpected instMag.f: %+v", f)
+	}
+	if q := spec.Child("q"); q == nil || q.Type != iec61850.MMS_BIT_STRING || q.Size != 13 {
+		t.Errorf("unexpected q: %+v", q)
+	}
+	if ts := spec.Child("t"); ts == nil || ts.Type != iec61850.MMS_UTC_TIME {
+		t.Errorf("unexpected t: %+v", ts)
+	}
+
+	// the returned specification is a copy of the cached one
+	spec.Children = nil
+	spec, err = client.GetTypeSpec("testSENSORS/LLN0.FLOAT", iec61850.IEC61850_FC_MX)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if spec.Child("q") == nil {
+		t.Error("cached specification was changed")
+	}
+
+	_, err = client.GetTypeSpec("testSENSORS/LLN0.NOTEXIST", iec61850.IEC61850_FC_MX)
+	if err == nil {
+		t.Error("specification of a not existing object")
+	}
+}
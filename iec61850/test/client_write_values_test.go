@@ -0,0 +1,46 @@
+package test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/weiheng-tech/go-libiec61850/iec61850"
+)
+
+func TestIEC61850ClientWriteValues(t *testing.T) {
+	client := iec61850.NewIedClient(iec61850.ConnectTimeout(time.Second * 5))
+	err := client.Connect("localhost", 102)
+	if err != nil {
+		fmt.Println("Error connecting:", err)
+		return
+	}
+	defer client.Close()
+
+	err = client.WriteInt32("simpleIOGenericIO/GGIO1.AnIn1.units.multiplier", iec61850.IEC61850_FC_CF, 3)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	err = client.WriteVisibleString("simpleIOGenericIO/GGIO1.NamPlt.d", iec61850.IEC61850_FC_DC, "Commissioned")
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	err = client.WriteUtcTime("simpleIOGenericIO/GGIO1.AnIn1.t", iec61850.IEC61850_FC_MX, time.Now())
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	err = client.WriteObject("simpleIOGenericIO/GGIO1.SPCSO1.ctlModel", iec61850.IEC61850_FC_CF, iec61850.Integer(1))
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	desc, err := client.ReadString("simpleIOGenericIO/GGIO1.NamPlt.d", iec61850.IEC61850_FC_DC)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Printf("read success, desc value: %+v\n", desc)
+}
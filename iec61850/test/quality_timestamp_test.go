@@ -0,0 +1,50 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/weiheng-tech/go-libiec61850/iec61850"
+)
+
+func TestIEC61850QualityFlags(t *testing.T) {
+	q := iec61850.Quality(iec61850.QUALITY_VALIDITY_QUESTIONABLE) | iec61850.QUALITY_DETAIL_OLD_DATA | iec61850.QUALITY_TEST
+
+	if q.Validity() != iec61850.QUALITY_VALIDITY_QUESTIONABLE {
+		t.Errorf("unexpected validity: %d", q.Validity())
+	}
+	if q.DetailQuality() != iec61850.QUALITY_DETAIL_OLD_DATA {
+		t.Errorf("unexpected detail quality: %d", q.DetailQuality())
+	}
+	if !q.Test() || q.Substituted() || q.OperatorBlocked() {
+		t.Errorf("unexpected flags: %d", q)
+	}
+
+	bs := q.BitString()
+	if bs.Size != 13 || bs.Uint32() != uint32(q) {
+		t.Errorf("unexpected bit string: %+v", bs)
+	}
+	// validity questionable is encoded as 11 in the first two bits
+	if !bs.Bit(0) || !bs.Bit(1) || !bs.Bit(7) || !bs.Bit(11) {
+		t.Errorf("unexpected bit string layout: %+v", bs)
+	}
+}
+
+func TestIEC61850TimestampRoundTrip(t *testing.T) {
+	now := time.UnixMilli(1700000000123)
+
+	ts := iec61850.NewTimestamp(now)
+	ts.ClockNotSynchronized = true
+	ts.SubsecondPrecision = 10
+
+	decoded := ts.UtcTime().Timestamp()
+	if decoded != ts {
+		t.Errorf("unexpected timestamp: %+v, expect: %+v", decoded, ts)
+	}
+	if decoded.Time().UnixMilli() != now.UnixMilli() {
+		t.Errorf("unexpected time: %v, expect: %v", decoded.Time(), now)
+	}
+	if decoded.UtcTime().TimeQuality() != 0x2a {
+		t.Errorf("unexpected time quality: %x", decoded.UtcTime().TimeQuality())
+	}
+}
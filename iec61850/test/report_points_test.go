@@ -0,0 +1,87 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/weiheng-tech/go-libiec61850/iec61850"
+	"github.com/weiheng-tech/go-libiec61850/iec61850/scl_xml"
+)
+
+func TestIEC61850ExplainReport(t *testing.T) {
+	scl, err := scl_xml.GetSCL("test_icd.icd")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dataSet, err := scl.GetDataSet("Huwor_JF204MONT/LLN0.dsAin1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	node, err := dataSet.ResolveFCDA(dataSet.FCDA[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	components := node.Components("MX")
+	if len(components) != 3 || components[0].Name != "mag" || components[0].Child("f") == nil || components[0].Child("f").BType != "FLOAT32" {
+		t.Fatalf("unexpected MX components of %s: %+v", node.Name, components)
+	}
+
+	values := make([]iec61850.MmsValue, len(dataSet.FCDA))
+	reasons := make([]iec61850.ReasonForInclusion, len(dataSet.FCDA))
+	values[0] = iec61850.Structure{
+		iec61850.Structure{iec61850.Float32(1.5)},
+		iec61850.Quality(0),
+		iec61850.Timestamp{},
+	}
+	reasons[0] = iec61850.REASON_DATA_CHANGE
+	values[4] = iec61850.Structure{
+		iec61850.Structure{iec61850.Float32(2.5)},
+		iec61850.Quality(0),
+		iec61850.Timestamp{},
+	}
+	reasons[4] = iec61850.REASON_GI | iec61850.REASON_QUALITY_CHANGE
+
+	points, err := iec61850.ExplainReport(iec61850.Report{RptID: "ain", Values: values, Reasons: reasons}, dataSet)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(points) != 6 {
+		t.Errorf("expect 6 points of 2 included members, got %v", points)
+	}
+	if point := points["Huwor_JF204MONT/SPDC1.AvDsch.mag.f"]; point.Value != iec61850.Float32(1.5) || point.Reason != iec61850.REASON_DATA_CHANGE {
+		t.Errorf("unexpected point: %+v", point)
+	}
+	if point, ok := points["Huwor_JF204MONT/SPDC1.AvDsch.q"]; !ok || point.Reason.String() != "dchg" {
+		t.Errorf("unexpected point: %+v", point)
+	}
+	if point := points["Huwor_JF204MONT/SPDC1.DschQ.mag.f"]; point.Value != iec61850.Float32(2.5) || point.Reason.String() != "qchg|GI" {
+		t.Errorf("unexpected point: %+v, %s", point, point.Reason)
+	}
+	if _, ok := points["Huwor_JF204MONT/SPDC1.MaxDsch.mag.f"]; ok {
+		t.Error("member not included in the report")
+	}
+
+	// a raw q keeps the size of its bit string
+	values[0] = iec61850.Structure{
+		iec61850.Structure{iec61850.Float32(1.5)},
+		iec61850.NewBitString(14, uint32(iec61850.QUALITY_TEST)),
+		iec61850.Timestamp{},
+	}
+	points, err = iec61850.ExplainReport(iec61850.Report{RptID: "ain", Values: values, Reasons: reasons}, dataSet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q, ok := points["Huwor_JF204MONT/SPDC1.AvDsch.q"].Value.(iec61850.Quality)
+	if !ok || !q.Test() || q.Has(iec61850.QUALITY_DERIVED) {
+		t.Fatalf("unexpected q: %#v", points["Huwor_JF204MONT/SPDC1.AvDsch.q"].Value)
+	}
+	if bs := q.BitString(); bs.Size != 14 || bs.Uint32() != uint32(iec61850.QUALITY_TEST) {
+		t.Errorf("unexpected bit string of q: %+v", bs)
+	}
+
+	if _, err := iec61850.ExplainReport(iec61850.Report{Values: values[:1], Reasons: reasons[:1]}, dataSet); err == nil {
+		t.Error("explained report of another data set")
+	}
+}
@@ -0,0 +1,77 @@
+package iec61850
+
+import "time"
+
+// Timestamp the decoded IEC 61850-8-1 UtcTime (TimeStamp) including the TimeQuality flags
+type Timestamp struct {
+	Seconds uint32
+	// FractionOfSecond the 24 bit binary fraction of a second
+	FractionOfSecond uint32
+
+	LeapSecondsKnown     bool
+	ClockFailure         bool
+	ClockNotSynchronized bool
+	// SubsecondPrecision the number of significant bits of FractionOfSecond, 31 means unspecified
+	SubsecondPrecision int
+}
+
+const (
+	timeQualityLeapSecondsKnown     = 0x80
+	timeQualityClockFailure         = 0x40
+	timeQualityClockNotSynchronized = 0x20
+	timeQualityAccuracyMask         = 0x1f
+)
+
+// NewTimestamp create a timestamp from t with an unspecified sub second precision
+func NewTimestamp(t time.Time) Timestamp {
+	fraction := ((uint64(t.Nanosecond()) << 24) + uint64(time.Second)/2) / uint64(time.Second)
+	if fraction > 0xffffff {
+		fraction = 0xffffff
+	}
+	return Timestamp{
+		Seconds:            uint32(t.Unix()),
+		FractionOfSecond:   uint32(fraction),
+		SubsecondPrecision: timeQualityAccuracyMask,
+	}
+}
+
+func (Timestamp) Type() MMSType { return MMS_UTC_TIME }
+
+func (t Timestamp) Time() time.Time {
+	nsec := (int64(t.FractionOfSecond)*int64(time.Second) + 1<<23) >> 24
+	return time.Unix(int64(t.Seconds), nsec)
+}
+
+func (t Timestamp) TimeQuality() uint8 {
+	tq := uint8(t.SubsecondPrecision) & timeQualityAccuracyMask
+	if t.LeapSecondsKnown {
+		tq |= timeQualityLeapSecondsKnown
+	}
+	if t.ClockFailure {
+		tq |= timeQualityClockFailure
+	}
+	if t.ClockNotSynchronized {
+		tq |= timeQualityClockNotSynchronized
+	}
+	return tq
+}
+
+func (t Timestamp) UtcTime() UtcTime {
+	return UtcTime{
+		byte(t.Seconds >> 24), byte(t.Seconds >> 16), byte(t.Seconds >> 8), byte(t.Seconds),
+		byte(t.FractionOfSecond >> 16), byte(t.FractionOfSecond >> 8), byte(t.FractionOfSecond),
+		t.TimeQuality(),
+	}
+}
+
+func (t UtcTime) Timestamp() Timestamp {
+	tq := t.TimeQuality()
+	return Timestamp{
+		Seconds:              t.Seconds(),
+		FractionOfSecond:     t.FractionOfSecond(),
+		LeapSecondsKnown:     tq&timeQualityLeapSecondsKnown != 0,
+		ClockFailure:         tq&timeQualityClockFailure != 0,
+		ClockNotSynchronized: tq&timeQualityClockNotSynchronized != 0,
+		SubsecondPrecision:   int(tq & timeQualityAccuracyMask),
+	}
+}
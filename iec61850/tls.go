@@ -0,0 +1,79 @@
+package iec61850
+
+// #include <iec61850_client.h>
+// #include <iec61850_server.h>
+import "C"
+import (
+	"errors"
+	"time"
+)
+
+// TLSVersion the TLS protocol versions, see TLSConfigVersion
+type TLSVersion int
+
+const (
+	TLS_VERSION_NOT_SELECTED TLSVersion = 0
+	TLS_VERSION_SSL_3_0      TLSVersion = 3
+	TLS_VERSION_TLS_1_0      TLSVersion = 4
+	TLS_VERSION_TLS_1_1      TLSVersion = 5
+	TLS_VERSION_TLS_1_2      TLSVersion = 6
+	TLS_VERSION_TLS_1_3      TLSVersion = 7
+)
+
+// ErrTLSNotSupported returned when the package is built without the tls build tag, TLS needs a libiec61850 built
+// with mbedtls
+var ErrTLSNotSupported = errors.New("tls is not supported, build with -tags tls and a libiec61850 with mbedtls")
+
+// TLSConfig the TLS settings of an MMS connection (IEC 62351-4, default port 3782). Certificates, keys and CRLs are
+// PEM or DER encoded.
+type TLSConfig struct {
+	// OwnCertificate and OwnKey the identity presented to the peer
+	OwnCertificate []byte
+	OwnKey         []byte
+	OwnKeyPassword string
+
+	// CACertificates the chain used to validate the peer certificate
+	CACertificates [][]byte
+	// AllowedCertificates when set only these peer certificates are accepted, even if others are signed by a CA
+	AllowedCertificates [][]byte
+	CRLs                [][]byte
+	// SkipChainValidation do not validate the peer certificate against CACertificates
+	SkipChainValidation bool
+
+	// MinVersion and MaxVersion TLS_VERSION_NOT_SELECTED keeps the default of libiec61850
+	MinVersion TLSVersion
+	MaxVersion TLSVersion
+
+	// RenegotiationTime the time after which the session is renegotiated, 0 keeps the default
+	RenegotiationTime time.Duration
+	// SessionResumptionInterval the maximum lifetime of a cached session, 0 keeps the default
+	SessionResumptionInterval time.Duration
+	DisableSessionResumption  bool
+}
+
+// NewIedClientWithTLS same as NewIedClient, but the connection is secured by TLS
+func NewIedClientWithTLS(config *TLSConfig, options ...Option) (*IedClient, error) {
+	tlsConfiguration, err := newTLSConfiguration(config, true)
+	if err != nil {
+		return nil, err
+	}
+
+	client := newIedClient(C.IedConnection_createWithTlsSupport(tlsConfiguration), options)
+	// the configuration is used by the connection until it is destroyed
+	client.tlsConfiguration = tlsConfiguration
+
+	return client, nil
+}
+
+// NewIedServerWithTLS same as NewIedServer, but the server only accepts TLS connections
+func NewIedServerWithTLS(model *IedModel, config *TLSConfig) (*IedServer, error) {
+	tlsConfiguration, err := newTLSConfiguration(config, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &IedServer{
+		server:           C.IedServer_createWithTlsSupport(model.model, tlsConfiguration),
+		tlsConfiguration: tlsConfiguration,
+	}, nil
+}
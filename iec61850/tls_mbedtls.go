@@ -0,0 +1,119 @@
+//go:build tls
+
+package iec61850
+
+// #include <stdlib.h>
+// #include <tls_config.h>
+import "C"
+import (
+	"bytes"
+	"fmt"
+	"unsafe"
+)
+
+func newTLSConfiguration(config *TLSConfig, clientMode bool) (C.TLSConfiguration, error) {
+	if config == nil {
+		return nil, fmt.Errorf("failed to create tls configuration, no TLSConfig")
+	}
+
+	tlsConfiguration := C.TLSConfiguration_create()
+	if tlsConfiguration == nil {
+		return nil, fmt.Errorf("failed to create tls configuration")
+	}
+
+	if err := applyTLSConfig(tlsConfiguration, config, clientMode); err != nil {
+		C.TLSConfiguration_destroy(tlsConfiguration)
+		return nil, err
+	}
+
+	return tlsConfiguration, nil
+}
+
+func applyTLSConfig(tlsConfiguration C.TLSConfiguration, config *TLSConfig, clientMode bool) error {
+	if clientMode {
+		C.TLSConfiguration_setClientMode(tlsConfiguration)
+	}
+
+	if len(config.OwnCertificate) > 0 {
+		if !withCBuffer(config.OwnCertificate, func(buffer *C.uint8_t, length C.int) C.bool {
+			return C.TLSConfiguration_setOwnCertificate(tlsConfiguration, buffer, length)
+		}) {
+			return fmt.Errorf("failed to set own certificate")
+		}
+	}
+
+	if len(config.OwnKey) > 0 {
+		var cPassword *C.char
+		if config.OwnKeyPassword != "" {
+			cPassword = C.CString(config.OwnKeyPassword)
+			defer C.free(unsafe.Pointer(cPassword))
+		}
+
+		if !withCBuffer(config.OwnKey, func(buffer *C.uint8_t, length C.int) C.bool {
+			return C.TLSConfiguration_setOwnKey(tlsConfiguration, buffer, length, cPassword)
+		}) {
+			return fmt.Errorf("failed to set own key")
+		}
+	}
+
+	for i, certificate := range config.CACertificates {
+		if !withCBuffer(certificate, func(buffer *C.uint8_t, length C.int) C.bool {
+			return C.TLSConfiguration_addCACertificate(tlsConfiguration, buffer, length)
+		}) {
+			return fmt.Errorf("failed to add ca certificate %d", i)
+		}
+	}
+
+	for i, certificate := range config.AllowedCertificates {
+		if !withCBuffer(certificate, func(buffer *C.uint8_t, length C.int) C.bool {
+			return C.TLSConfiguration_addAllowedCertificate(tlsConfiguration, buffer, length)
+		}) {
+			return fmt.Errorf("failed to add allowed certificate %d", i)
+		}
+	}
+	C.TLSConfiguration_setAllowOnlyKnownCertificates(tlsConfiguration, C.bool(len(config.AllowedCertificates) > 0))
+
+	for i, crl := range config.CRLs {
+		if !withCBuffer(crl, func(buffer *C.uint8_t, length C.int) C.bool {
+			return C.TLSConfiguration_addCRL(tlsConfiguration, buffer, length)
+		}) {
+			return fmt.Errorf("failed to add crl %d", i)
+		}
+	}
+
+	C.TLSConfiguration_setChainValidation(tlsConfiguration, C.bool(!config.SkipChainValidation))
+
+	if config.MinVersion != TLS_VERSION_NOT_SELECTED {
+		C.TLSConfiguration_setMinTlsVersion(tlsConfiguration, C.TLSConfigVersion(config.MinVersion))
+	}
+	if config.MaxVersion != TLS_VERSION_NOT_SELECTED {
+		C.TLSConfiguration_setMaxTlsVersion(tlsConfiguration, C.TLSConfigVersion(config.MaxVersion))
+	}
+
+	if config.RenegotiationTime > 0 {
+		C.TLSConfiguration_setRenegotiationTime(tlsConfiguration, C.int(config.RenegotiationTime.Milliseconds()))
+	}
+
+	C.TLSConfiguration_enableSessionResumption(tlsConfiguration, C.bool(!config.DisableSessionResumption))
+	if config.SessionResumptionInterval > 0 {
+		C.TLSConfiguration_setSessionResumptionInterval(tlsConfiguration, C.int(config.SessionResumptionInterval.Seconds()))
+	}
+
+	return nil
+}
+
+// withCBuffer pass data in C memory, mbedtls only parses PEM data including the terminating zero byte
+func withCBuffer(data []byte, f func(*C.uint8_t, C.int) C.bool) bool {
+	if bytes.Contains(data, []byte("-----BEGIN")) && data[len(data)-1] != 0 {
+		data = append(append([]byte(nil), data...), 0)
+	}
+
+	buffer := C.CBytes(data)
+	defer C.free(buffer)
+
+	return bool(f((*C.uint8_t)(buffer), C.int(len(data))))
+}
+
+func destroyTLSConfiguration(tlsConfiguration C.TLSConfiguration) {
+	C.TLSConfiguration_destroy(tlsConfiguration)
+}
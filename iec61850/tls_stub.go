@@ -0,0 +1,13 @@
+//go:build !tls
+
+package iec61850
+
+// #include <tls_config.h>
+import "C"
+
+func newTLSConfiguration(config *TLSConfig, clientMode bool) (C.TLSConfiguration, error) {
+	return nil, ErrTLSNotSupported
+}
+
+func destroyTLSConfiguration(tlsConfiguration C.TLSConfiguration) {
+}
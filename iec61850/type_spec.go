@@ -0,0 +1,226 @@
+package iec61850
+
+// #include <iec61850_client.h>
+import "C"
+import (
+	"strconv"
+	"unsafe"
+)
+
+// MmsVariableSpecification the type of an MMS variable, see IedConnection_getVariableSpecification
+type MmsVariableSpecification struct {
+	// Name the component name, empty for the object itself and for array elements
+	Name string
+	Type MMSType
+	// Size the number of bits of a bit string or an integer, the (maximum) length of an octet string or a string,
+	// the size (4 or 6) of a binary time
+	Size int
+	// FloatWidth the format width (32 or 64) and ExponentWidth the exponent width (8 or 11) of a float
+	FloatWidth    int
+	ExponentWidth int
+	// ElementCount the number of array elements or structure components
+	ElementCount int
+	// Children the components of a structure
+	Children []*MmsVariableSpecification
+	// Element the type of the elements of an array
+	Element *MmsVariableSpecification
+}
+
+// Child the component of a structure by name, nil if not found
+func (spec *MmsVariableSpecification) Child(name string) *MmsVariableSpecification {
+	for _, child := range spec.Children {
+		if child.Name == name {
+			return child
+		}
+	}
+	return nil
+}
+
+// clone a deep copy, so the cached specification can not be changed by the caller
+func (spec *MmsVariableSpecification) clone() *MmsVariableSpecification {
+	if spec == nil {
+		return nil
+	}
+
+	goSpec := *spec
+	if spec.Children != nil {
+		goSpec.Children = make([]*MmsVariableSpecification, len(spec.Children))
+		for i, child := range spec.Children {
+			goSpec.Children[i] = child.clone()
+		}
+	}
+	goSpec.Element = spec.Element.clone()
+
+	return &goSpec
+}
+
+func newVariableSpec(spec *C.MmsVariableSpecification) *MmsVariableSpecification {
+	goSpec := &MmsVariableSpecification{
+		Type: MMSType(C.MmsVariableSpecification_getType(spec)),
+	}
+
+	if name := C.MmsVariableSpecification_getName(spec); name != nil {
+		goSpec.Name = C.GoString(name)
+	}
+
+	size := int(C.MmsVariableSpecification_getSize(spec))
+
+	switch goSpec.Type {
+	case MMS_STRUCTURE:
+		goSpec.ElementCount = size
+		goSpec.Children = make([]*MmsVariableSpecification, size)
+		for i := 0; i < size; i++ {
+			goSpec.Children[i] = newVariableSpec(C.MmsVariableSpecification_getChildSpecificationByIndex(spec, C.int(i)))
+		}
+	case MMS_ARRAY:
+		goSpec.ElementCount = size
+		goSpec.Element = newVariableSpec(C.MmsVariableSpecification_getArrayElementSpecification(spec))
+	case MMS_FLOAT:
+		goSpec.FloatWidth = size
+		goSpec.ExponentWidth = int(C.MmsVariableSpecification_getExponentWidth(spec))
+	case MMS_INTEGER, MMS_UNSIGNED, MMS_BIT_STRING, MMS_OCTET_STRING, MMS_VISIBLE_STRING, MMS_STRING, MMS_BINARY_TIME:
+		goSpec.Size = size
+	}
+
+	return goSpec
+}
+
+// GetTypeSpec read the type of a functional constrained data (attribute), e.g. the component names of "LD0/MMXU1.TotW"
+// with IEC61850_FC_MX. The specification is read once per connection and cached.
+func (client *IedClient) GetTypeSpec(objectRef string, constraint FunctionalConstraint) (*MmsVariableSpecification, error) {
+	spec, err := client.getVariableSpec(objectRef, constraint)
+	if err != nil {
+		return nil, err
+	}
+	return spec.clone(), nil
+}
+
+// getVariableSpec the specification of an object does not change during an association, so it is cached until the
+// client connects again
+func (client *IedClient) getVariableSpec(objectRef string, constraint FunctionalConstraint) (*MmsVariableSpecification, error) {
+	key := specKey(objectRef, constraint)
+
+	client.specMutex.Lock()
+	spec, ok := client.specCache[key]
+	client.specMutex.Unlock()
+	if ok {
+		return spec, nil
+	}
+
+	if err := client.acquire(); err != nil {
+		return nil, err
+	}
+	defer client.release()
+
+	cObjectRef := C.CString(objectRef)
+	defer C.free(unsafe.Pointer(cObjectRef))
+
+	var clientError C.IedClientError
+	cSpec := C.IedConnection_getVariableSpecification(client.connection, &clientError, cObjectRef, C.FunctionalConstraint(constraint))
+
+	if clientError != C.IED_ERROR_OK {
+		return nil, newClientError("get variable specification", objectRef, clientError)
+	}
+
+	defer C.MmsVariableSpecification_destroy(cSpec)
+
+	spec = newVariableSpec(cSpec)
+
+	client.specMutex.Lock()
+	if client.specCache == nil {
+		client.specCache = make(map[string]*MmsVariableSpecification)
+	}
+	client.specCache[key] = spec
+	client.specMutex.Unlock()
+
+	return spec, nil
+}
+
+// flattenValue map every component of value to its path below the object, e.g. "mag.f", "q", "har(0).cVal.mag.f"
+func flattenValue(spec *MmsVariableSpecification, value MmsValue, path string, out map[string]MmsValue) {
+	if path != "" {
+		out[path] = value
+	}
+
+	switch v := value.(type) {
+	case Structure:
+		if spec == nil || spec.Type != MMS_STRUCTURE {
+			return
+		}
+		for i, element := range v {
+			if i >= len(spec.Children) {
+				return
+			}
+			flattenValue(spec.Children[i], element, joinPath(path, spec.Children[i].Name), out)
+		}
+	case Array:
+		if spec == nil || spec.Type != MMS_ARRAY {
+			return
+		}
+		for i, element := range v.Elements {
+			flattenValue(spec.Element, element, path+"("+strconv.Itoa(i)+")", out)
+		}
+	}
+}
+
+// flattenLeaves like flattenValue, but only the basic (not structure or array) components are mapped
+func flattenLeaves(spec *MmsVariableSpecification, value MmsValue, path string, out map[string]MmsValue) {
+	switch v := value.(type) {
+	case Structure:
+		if spec != nil && spec.Type == MMS_STRUCTURE && len(spec.Children) >= len(v) {
+			for i, element := range v {
+				flattenLeaves(spec.Children[i], element, joinPath(path, spec.Children[i].Name), out)
+			}
+			return
+		}
+	case Array:
+		if spec != nil && spec.Type == MMS_ARRAY {
+			for i, element := range v.Elements {
+				flattenLeaves(spec.Element, element, path+"("+strconv.Itoa(i)+")", out)
+			}
+			return
+		}
+	}
+
+	out[path] = value
+}
+
+func joinPath(path string, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func specKey(objectRef string, constraint FunctionalConstraint) string {
+	return objectRef + "[" + strconv.Itoa(int(constraint)) + "]"
+}
+
+// clearSpecCache forget the specifications of the last association, the IED may have changed
+func (client *IedClient) clearSpecCache() {
+	client.specMutex.Lock()
+	client.specCache = nil
+	client.specRejected = nil
+	client.specMutex.Unlock()
+}
+
+// readFlattened read a whole data object in one request and map its components by name
+func (client *IedClient) readFlattened(objectRef string, constraint FunctionalConstraint) (map[string]MmsValue, error) {
+	spec, err := client.getVariableSpec(objectRef, constraint)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := client.ReadObject(objectRef, constraint)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]MmsValue)
+	flattenValue(spec, value, "", values)
+	for path, v := range values {
+		values[path] = client.decodeNamed(path, v)
+	}
+
+	return values, nil
+}